@@ -0,0 +1,38 @@
+package chef
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBaselineFileName is a default file name to store baseline.
+const DefaultBaselineFileName = ".chef.baseline.yml"
+
+// Baseline records the content generated files were rendered with, keyed by
+// the file location relative to the project. The baseline is the common
+// ancestor of the file edited in the project and the file rendered with the
+// upgraded templates.
+type Baseline map[string]string
+
+// Write writes baseline to provided output.
+func (b Baseline) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(map[string]string(b)); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// ReadBaseline reads baseline from provided source.
+func ReadBaseline(r io.Reader) (Baseline, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := make(Baseline)
+	err = yaml.Unmarshal(b, &baseline)
+	return baseline, err
+}
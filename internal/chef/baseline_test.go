@@ -0,0 +1,33 @@
+package chef_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	b := chef.Baseline{
+		"go.mod":                 "module app\n\ngo 1.23\n",
+		"handler/http/router.go": "package http\n\nfunc NewHandler() {\n\treturn\n}\n",
+		"empty.txt":              "",
+	}
+
+	var buf bytes.Buffer
+	err := b.Write(&buf)
+	require.NoError(t, err)
+
+	actual, err := chef.ReadBaseline(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, b, actual)
+}
+
+func TestReadEmptyBaseline(t *testing.T) {
+	b, err := chef.ReadBaseline(&bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Empty(t, b)
+	assert.NotNil(t, b)
+}
@@ -0,0 +1,56 @@
+package chef
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotationNotFound is returned when no notation found in the directory or
+// its parents.
+var ErrNotationNotFound = errors.New(DefaultNotationFileName + " not found in the directory or its parents")
+
+// vcsDirs are the names of version control directories marking the root of
+// a repository.
+var vcsDirs = []string{".git", ".hg", ".svn", ".bzr"}
+
+// FindRoot returns the project root: the closest directory containing the
+// notation file, starting from dir and walking up its parents. Search stops at
+// the root of a version control repository or the file system root.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if isFile(filepath.Join(dir, DefaultNotationFileName)) {
+			return dir, nil
+		}
+		if isRepositoryRoot(dir) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", ErrNotationNotFound
+}
+
+func isRepositoryRoot(dir string) bool {
+	for _, vcs := range vcsDirs {
+		if _, err := os.Stat(filepath.Join(dir, vcs)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isFile(name string) bool {
+	fi, err := os.Stat(name)
+	return err == nil && !fi.IsDir()
+}
@@ -0,0 +1,55 @@
+package chef_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRoot(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(path.Join(repo, ".git"), 0755))
+	project := path.Join(repo, "project")
+	nested := path.Join(project, "handler", "http")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.NoError(t, os.WriteFile(path.Join(project, chef.DefaultNotationFileName), nil, 0644))
+
+	testCases := []struct {
+		desc string
+		dir  string
+	}{
+		{
+			desc: "finds notation in the directory",
+			dir:  project,
+		},
+		{
+			desc: "finds notation in parent directories",
+			dir:  nested,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			root, err := chef.FindRoot(tC.dir)
+			require.NoError(t, err)
+			assert.Equal(t, project, root)
+		})
+	}
+
+	t.Run("stops at repository root", func(t *testing.T) {
+		other := path.Join(repo, "other")
+		require.NoError(t, os.Mkdir(other, 0755))
+		// notation above the repository root is not found
+		require.NoError(t, os.WriteFile(path.Join(path.Dir(repo), chef.DefaultNotationFileName), nil, 0644))
+		t.Cleanup(func() {
+			os.Remove(path.Join(path.Dir(repo), chef.DefaultNotationFileName))
+		})
+
+		root, err := chef.FindRoot(other)
+		assert.ErrorIs(t, err, chef.ErrNotationNotFound)
+		assert.Empty(t, root)
+	})
+}
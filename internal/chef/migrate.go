@@ -0,0 +1,199 @@
+package chef
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// SchemaVersion is the notation schema version supported by chef.
+//
+// Schema versions:
+//
+//  1. category, server, module and custom components with the template file
+//     location in the template field. Notations without schema are version 1.
+//  2. layout, registered components with the template name and the template
+//     file, and the log of employed components.
+const SchemaVersion = 2
+
+// BackupExt is the extension of the notation backup created by migration.
+const BackupExt = ".bak"
+
+// document is a generic notation document migrations operate on.
+type document = map[interface{}]interface{}
+
+// migration upgrades notation document to the next schema version.
+type migration func(document) error
+
+// migrations registry. The key is the schema version the migration upgrades
+// from.
+var migrations = map[int]migration{
+	1: migrateV1,
+}
+
+// ErrNewerSchema is returned when notation schema version is newer than
+// the supported one.
+type ErrNewerSchema struct {
+	Schema int
+}
+
+func (e ErrNewerSchema) Error() string {
+	return fmt.Sprintf("notation schema version %d is newer than supported version %d, upgrade chef",
+		e.Schema, SchemaVersion)
+}
+
+// MigrateNotation upgrades the notation file to the current schema version.
+// The original file is kept next to the notation with BackupExt extension,
+// migration fails when the backup already exists. The upgraded notation
+// replaces the file atomically. It returns the schema version the notation
+// was migrated from, which equals to SchemaVersion when notation is up to
+// date.
+func MigrateNotation(file string) (int, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	schema, err := readSchema(b)
+	if err != nil {
+		return 0, err
+	}
+	if schema == SchemaVersion {
+		return schema, nil
+	}
+
+	n, err := ReadNotation(bytes.NewReader(b))
+	if err != nil {
+		return 0, err
+	}
+
+	fi, err := os.Stat(file)
+	if err != nil {
+		return 0, err
+	}
+	if err := backup(file+BackupExt, b, fi.Mode().Perm()); err != nil {
+		return 0, errors.Wrap(err, "failed to backup notation")
+	}
+
+	if err := replace(file, n, fi.Mode().Perm()); err != nil {
+		return 0, errors.Wrap(err, "failed to write notation")
+	}
+	return schema, nil
+}
+
+// backup writes the notation content to the backup file. It does not
+// overwrite an existing backup.
+func backup(file string, b []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if os.IsExist(err) {
+		return fmt.Errorf("%s already exists", file)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// replace writes the notation to a temporary file next to the notation file
+// and renames it over the notation file.
+func replace(file string, n Notation, perm os.FileMode) error {
+	f, err := os.CreateTemp(path.Dir(file), "."+path.Base(file)+"-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // no-op after successful rename
+
+	if err := n.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// readSchema returns schema version of the notation.
+func readSchema(b []byte) (int, error) {
+	var header struct {
+		Schema int
+	}
+	if err := yaml.Unmarshal(b, &header); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case header.Schema == 0:
+		return 1, nil
+	case header.Schema > SchemaVersion:
+		return 0, ErrNewerSchema{Schema: header.Schema}
+	}
+	return header.Schema, nil
+}
+
+// migrate applies migrations to upgrade the notation from the schema version
+// to the current one.
+func migrate(b []byte, schema int) ([]byte, error) {
+	doc := make(document)
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	for v := schema; v < SchemaVersion; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration from notation schema version %d", v)
+		}
+		if err := m(doc); err != nil {
+			return nil, errors.Wrapf(err, "failed to migrate notation from schema version %d", v)
+		}
+	}
+	doc["schema"] = SchemaVersion
+
+	return yaml.Marshal(doc)
+}
+
+// migrateV1 moves the template file location of custom components to the
+// file field. The template is named after the file. Values with a path
+// separator or an extension are file locations, components referencing
+// templates by name are not changed.
+func migrateV1(doc document) error {
+	comps, ok := doc["components"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	for i, c := range comps {
+		comp, ok := c.(map[interface{}]interface{})
+		if !ok {
+			return fmt.Errorf("component %d is not a mapping", i)
+		}
+		file, _ := comp["template"].(string)
+		if _, ok := comp["file"]; ok || !isFileLoc(file) {
+			continue
+		}
+		comp["file"] = file
+		comp["template"] = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	return nil
+}
+
+// isFileLoc reports whether the schema 1 template value is a file location
+// rather than a template name.
+func isFileLoc(v string) bool {
+	return strings.Contains(v, "/") || path.Ext(v) != ""
+}
@@ -0,0 +1,161 @@
+package chef_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const notationV1 = `version: unknown
+category: srv
+server: http
+components:
+- name: repo
+  loc: provider
+  template: tmpl/repo.tmpl
+  desc: Repository
+- name: http_handler
+  loc: handler/http
+  template: http_endpoint
+- name: handler
+  loc: handler
+  template: templates/handler
+- name: service
+  loc: app
+  template: service
+`
+
+func TestReadNotationMigrates(t *testing.T) {
+	n, err := chef.ReadNotation(strings.NewReader(notationV1))
+	require.NoError(t, err)
+
+	expected := chef.Notation{
+		Category: "srv",
+		Server:   "http",
+		Components: []chef.Component{
+			{Name: "repo", Loc: "provider", Template: "repo", File: "tmpl/repo.tmpl", Desc: "Repository"},
+			{Name: "http_handler", Loc: "handler/http", Template: "http_endpoint"},
+			{Name: "handler", Loc: "handler", Template: "handler", File: "templates/handler"},
+			{Name: "service", Loc: "app", Template: "service"},
+		},
+	}
+	assert.Equal(t, expected, n)
+}
+
+func TestReadNotationFails(t *testing.T) {
+	testCases := []struct {
+		desc string
+		src  string
+		err  string
+	}{
+		{
+			desc: "when notation schema is newer than supported",
+			src:  "version: unknown\nschema: 100\ncategory: srv",
+			err:  "notation schema version 100 is newer than supported version 2, upgrade chef",
+		},
+		{
+			desc: "when notation component is not a mapping",
+			src:  "version: unknown\ncategory: srv\ncomponents:\n- repo",
+			err:  "failed to migrate notation from schema version 1: component 0 is not a mapping",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := chef.ReadNotation(strings.NewReader(tC.src))
+			assert.EqualError(t, err, tC.err)
+		})
+	}
+}
+
+func TestMigrateNotation(t *testing.T) {
+	t.Run("upgrades notation and keeps backup", func(t *testing.T) {
+		file := path.Join(t.TempDir(), chef.DefaultNotationFileName)
+		err := os.WriteFile(file, []byte(notationV1), 0644)
+		require.NoError(t, err)
+
+		from, err := chef.MigrateNotation(file)
+		require.NoError(t, err)
+		assert.Equal(t, 1, from)
+
+		backup, err := os.ReadFile(file + chef.BackupExt)
+		require.NoError(t, err)
+		assert.Equal(t, notationV1, string(backup))
+
+		fi, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+		entries, err := os.ReadDir(path.Dir(file))
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		expected := `version: unknown
+schema: 2
+category: srv
+server: http
+components:
+- name: repo
+  loc: provider
+  template: repo
+  file: tmpl/repo.tmpl
+  desc: Repository
+- name: http_handler
+  loc: handler/http
+  template: http_endpoint
+- name: handler
+  loc: handler
+  template: handler
+  file: templates/handler
+- name: service
+  loc: app
+  template: service`
+		assert.YAMLEq(t, expected, string(data))
+	})
+
+	t.Run("does not change up to date notation", func(t *testing.T) {
+		file := path.Join(t.TempDir(), chef.DefaultNotationFileName)
+		src := "version: unknown\nschema: 2\ncategory: srv\n"
+		err := os.WriteFile(file, []byte(src), 0644)
+		require.NoError(t, err)
+
+		from, err := chef.MigrateNotation(file)
+		require.NoError(t, err)
+		assert.Equal(t, chef.SchemaVersion, from)
+
+		_, err = os.Stat(file + chef.BackupExt)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("fails when backup exists", func(t *testing.T) {
+		file := path.Join(t.TempDir(), chef.DefaultNotationFileName)
+		err := os.WriteFile(file, []byte(notationV1), 0644)
+		require.NoError(t, err)
+		err = os.WriteFile(file+chef.BackupExt, []byte("previous backup"), 0644)
+		require.NoError(t, err)
+
+		_, err = chef.MigrateNotation(file)
+		assert.EqualError(t, err, "failed to backup notation: "+file+chef.BackupExt+" already exists")
+
+		backup, err := os.ReadFile(file + chef.BackupExt)
+		require.NoError(t, err)
+		assert.Equal(t, "previous backup", string(backup))
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, notationV1, string(data))
+	})
+
+	t.Run("fails when notation is newer than supported", func(t *testing.T) {
+		file := path.Join(t.TempDir(), chef.DefaultNotationFileName)
+		err := os.WriteFile(file, []byte("version: unknown\nschema: 3\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = chef.MigrateNotation(file)
+		assert.EqualError(t, err, "notation schema version 3 is newer than supported version 2, upgrade chef")
+	})
+}
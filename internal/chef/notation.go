@@ -3,11 +3,10 @@ package chef
 import (
 	"io"
 
-	"gopkg.in/yaml.v2"
+	"github.com/antklim/chef/internal/layout"
+	"gopkg.in/yaml.v3"
 )
 
-// TODO: add layout information to notation
-
 // TODO: split notation into parts - generic information like category and
 // language specific information like module name.
 
@@ -20,24 +19,77 @@ const DefaultNotationFileName = ".chef.yml"
 
 type notation struct {
 	Version  string
+	Schema   int
 	Notation `yaml:",inline"`
 }
 
 // Notation defines chef project notation.
 type Notation struct {
-	Category string
-	Server   string `yaml:",omitempty"`
-	Module   string `yaml:",omitempty"` // Go module name
+	Category  string
+	Server    string `yaml:",omitempty"`
+	Module    string `yaml:",omitempty"` // Go module name
+	GoVersion string `yaml:",omitempty"` // Go version of the module
+	Templates string `yaml:",omitempty"` // user templates location relative to the project
+
+	Layout     *layout.Definition `yaml:",omitempty"` // project layout without employed nodes
+	Components []Component        `yaml:",omitempty"` // registered project components
+	Employed   []Employed         `yaml:",omitempty"` // log of employed components
+}
+
+// Component defines registered project component.
+type Component struct {
+	Name     string
+	Loc      string // location in the project layout where component adds nodes
+	Template string // name of the component template
+	File     string `yaml:",omitempty"` // template file location relative to the project, set for custom components
+	Desc     string `yaml:",omitempty"`
+	Ext      string `yaml:",omitempty"` // extension of created files, by default ".go"
+	Lang     string `yaml:",omitempty"` // language of created files, by default derived from the extension
+
+	Outputs []Output `yaml:",omitempty"` // additional files created by the component
+	Params  []Param  `yaml:",omitempty"` // typed inputs of the component templates
+	Naming  string   `yaml:",omitempty"` // rule of the component elements names: ident, kebab or file
+}
+
+// Param defines a typed input of the component.
+type Param struct {
+	Name    string
+	Type    string   // string, bool, enum or list
+	Default string   `yaml:",omitempty"`
+	Values  []string `yaml:",omitempty"` // allowed values of enum parameter
+	Desc    string   `yaml:",omitempty"`
+}
+
+// Output defines an additional file created by the component.
+type Output struct {
+	Loc      string // location in the project layout where the file is created
+	Pattern  string // file name template, for example "{{.Name}}_test.go"
+	Template string // name of the file template
+	File     string `yaml:",omitempty"` // template file location relative to the project, set for custom components
+	Lang     string `yaml:",omitempty"` // language of the file, by default derived from the file extension
+}
+
+// Employed describes an instance of the component employed in the project.
+type Employed struct {
+	Component string
+	Name      string
+	Path      string // location of the created file relative to the project
+	Template  string // name of the template the file was created with
+	Version   string // version of the template the file was created with
+
+	Params map[string]string `yaml:",omitempty"` // values of the component parameters
 }
 
 // Write writes notation to provided output.
 func (n Notation) Write(w io.Writer) error {
 	nttn := notation{
 		Version:  version,
+		Schema:   SchemaVersion,
 		Notation: n,
 	}
 
 	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
 	if err := enc.Encode(nttn); err != nil {
 		return err
 	}
@@ -45,10 +97,28 @@ func (n Notation) Write(w io.Writer) error {
 	return enc.Close()
 }
 
-// ReadNotation reads notation from provided source.
+// ReadNotation reads notation from provided source. Notations of the previous
+// schema versions are migrated to the current schema version. It fails when
+// notation schema version is newer than the supported one, or when the layout
+// definition is invalid.
 func ReadNotation(r io.Reader) (Notation, error) {
-	dec := yaml.NewDecoder(r)
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return Notation{}, err
+	}
+
+	schema, err := readSchema(b)
+	if err != nil {
+		return Notation{}, err
+	}
+
+	if schema < SchemaVersion {
+		if b, err = migrate(b, schema); err != nil {
+			return Notation{}, err
+		}
+	}
+
 	var nttn notation
-	err := dec.Decode(&nttn)
+	err = yaml.Unmarshal(b, &nttn)
 	return nttn.Notation, err
 }
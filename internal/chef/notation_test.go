@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/layout"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotationWrite(t *testing.T) {
@@ -16,6 +18,7 @@ func TestNotationWrite(t *testing.T) {
 	assert.NoError(t, err)
 
 	expected := `version: unknown
+schema: 2
 category: srv
 server: http`
 	assert.YAMLEq(t, expected, buf.String())
@@ -35,3 +38,130 @@ server: http`)
 	assert.NoError(t, err)
 	assert.Equal(t, expected, notation)
 }
+
+func TestNotationWriteTemplates(t *testing.T) {
+	n := chef.Notation{Category: "srv", Templates: "../tmpl"}
+
+	var buf bytes.Buffer
+	err := n.Write(&buf)
+	assert.NoError(t, err)
+
+	expected := `version: unknown
+schema: 2
+category: srv
+templates: ../tmpl`
+	assert.YAMLEq(t, expected, buf.String())
+}
+
+func TestNotationComponents(t *testing.T) {
+	n := chef.Notation{
+		Category: "srv",
+		Components: []chef.Component{
+			{Name: "repo", Loc: "provider", Template: "repo", File: "tmpl/repo.tmpl", Desc: "Repository"},
+			{Name: "http_handler", Loc: "handler/http", Template: "http_endpoint"},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := n.Write(&buf)
+	assert.NoError(t, err)
+
+	expected := `version: unknown
+schema: 2
+category: srv
+components:
+- name: repo
+  loc: provider
+  template: repo
+  file: tmpl/repo.tmpl
+  desc: Repository
+- name: http_handler
+  loc: handler/http
+  template: http_endpoint`
+	assert.YAMLEq(t, expected, buf.String())
+
+	notation, err := chef.ReadNotation(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n, notation)
+}
+
+func TestReadNotationLayout(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		notation string
+		err      string
+	}{
+		{
+			desc: "fails when layout node has unknown field",
+			notation: `schema: 2
+category: srv
+layout:
+  nodes:
+  - dir: handler
+    mode: "0755"`,
+			err: `line 6: unknown field "mode"`,
+		},
+		{
+			desc: "fails when layout node is not a mapping",
+			notation: `schema: 2
+category: srv
+layout:
+  nodes:
+  - handler`,
+			err: "line 5: node definition must be a mapping",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := chef.ReadNotation(bytes.NewBufferString(tC.notation))
+			assert.EqualError(t, err, tC.err)
+		})
+	}
+}
+
+func TestNotationLayoutAndEmployed(t *testing.T) {
+	n := chef.Notation{
+		Category: "srv",
+		Layout: &layout.Definition{Nodes: []layout.NodeDefinition{
+			{Dir: "handler", Perm: "0755", Nodes: []layout.NodeDefinition{
+				{File: "router.go", Perm: "0644", Template: "http_router"},
+			}},
+		}},
+		Employed: []chef.Employed{
+			{Component: "http_handler", Name: "echo", Path: "handler/echo.go", Template: "http_endpoint", Version: "90fd6c34941c"},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := n.Write(&buf)
+	assert.NoError(t, err)
+
+	expected := `version: unknown
+schema: 2
+category: srv
+layout:
+  nodes:
+  - dir: handler
+    perm: "0755"
+    nodes:
+    - file: router.go
+      perm: "0644"
+      template: http_router
+employed:
+- component: http_handler
+  name: echo
+  path: handler/echo.go
+  template: http_endpoint
+  version: 90fd6c34941c`
+	assert.YAMLEq(t, expected, buf.String())
+
+	notation, err := chef.ReadNotation(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, n.Employed, notation.Employed)
+
+	buf.Reset()
+	err = notation.Write(&buf)
+	require.NoError(t, err)
+	assert.YAMLEq(t, expected, buf.String())
+}
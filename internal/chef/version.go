@@ -1,4 +1,5 @@
 package chef
 
-// version defines chef notation version.
+// version defines the version of chef that writes notation. Notation format
+// is versioned by SchemaVersion.
 var version = "unknown"
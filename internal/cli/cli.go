@@ -4,14 +4,108 @@ import (
 	"io"
 	"os"
 
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/project"
+	"github.com/pkg/errors"
 )
 
 var printout io.Writer = os.Stdout
 
+// printerr is the output of errors, it keeps structured documents printed to
+// printout valid when a command fails.
+var printerr io.Writer = os.Stderr
+
 type Project interface {
 	Init() error
 	Build() (string, error)
 	Components() []project.Component
-	EmployComponent(string, string) error
+	AddComponent(chef.Component, bool) error
+	EmployComponent(string, string, map[string]string) ([]string, error)
+	UnemployComponent(string, string, bool) ([]string, error)
+	RenameComponent(string, string, string, bool) ([]string, error)
+	Plan() ([]fsys.Entry, error)
+	PlanComponent(string, string, map[string]string) ([]fsys.Entry, error)
+	Verify() error
+	Inspect() ([]project.NodeInfo, error)
+	Doctor() ([]project.Finding, error)
+	Sync(bool) (project.SyncReport, error)
+	PlanSync(bool) ([]fsys.Entry, error)
+	Upgrade() (project.UpgradeReport, error)
+	Paths() []string
+	Dependencies() []string
+	Loc() string
+}
+
+// globals are the values of the flags available to all commands.
+var globals struct {
+	Project string // project location
+	Verbose bool
+	Output  string // output format name
+}
+
+// output is the output format selected by the output flag.
+var output = display.Text
+
+var (
+	projectLoc = Flag{
+		LongForm:   "project",
+		Help:       "Location of the project, by default the project is searched from the working directory up.",
+		IsRequired: false,
+	}
+	verbose = Flag{
+		LongForm:   "verbose",
+		Help:       "Verbose output.",
+		IsRequired: false,
+	}
+	outputFormat = Flag{
+		LongForm:   "output",
+		ShortForm:  "o",
+		Help:       "Output format: text, json or yaml.",
+		IsRequired: false,
+	}
+)
+
+var dryRun = Flag{
+	LongForm:   "dry-run",
+	Help:       "Print planned changes without writing them to the file system.",
+	IsRequired: false,
+}
+
+// projectRoot returns the root of the project. The root is searched from
+// the project location flag, or the working directory, walking up to the
+// directory containing the project notation.
+func projectRoot() (string, error) {
+	start := globals.Project
+	if start == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get working directory")
+		}
+		start = dir
+	}
+
+	root, err := chef.FindRoot(start)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find project from %q", start)
+	}
+
+	// verbose output would break structured documents
+	if globals.Verbose && !output.Structured() {
+		if err := display.ProjectRoot(printout, root); err != nil {
+			return "", err
+		}
+	}
+	return root, nil
+}
+
+// setOutput sets the output format from the output flag.
+func setOutput() error {
+	f, err := display.ParseFormat(globals.Output)
+	if err != nil {
+		return err
+	}
+	output = f
+	return nil
 }
@@ -3,6 +3,8 @@ package cli
 import (
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/antklim/chef/internal/chef"
 	"github.com/antklim/chef/internal/display"
@@ -11,12 +13,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// TODO: by default employ component adds a component to the current
-// directory (assume current directory is a root of the project).
-// Add ability to provide a project name and location.
-
-// TODO: add register component
-
 var (
 	component = Flag{
 		LongForm:   "component",
@@ -30,6 +26,87 @@ var (
 		Help:       "Name of the node to be created employing the component.",
 		IsRequired: true,
 	}
+	componentSet = Flag{
+		LongForm:   "set",
+		Help:       "Value of the component parameter as <key>=<value>, can be repeated.",
+		IsRequired: false,
+	}
+	force = Flag{
+		LongForm:   "force",
+		Help:       "Apply changes to the files modified since they were generated.",
+		IsRequired: false,
+	}
+	unemployName = Flag{
+		LongForm:   "name",
+		ShortForm:  "n",
+		Help:       "Name of the employed component instance.",
+		IsRequired: true,
+	}
+	renameTo = Flag{
+		LongForm:   "to",
+		Help:       "New name of the employed component instance.",
+		IsRequired: true,
+	}
+	registerName = Flag{
+		LongForm:   "name",
+		ShortForm:  "n",
+		Help:       "Name of the component to register.",
+		IsRequired: true,
+	}
+	registerLoc = Flag{
+		LongForm:   "loc",
+		ShortForm:  "l",
+		Help:       "Location in the project layout where the component adds nodes.",
+		IsRequired: true,
+	}
+	registerTemplate = Flag{
+		LongForm:   "template",
+		ShortForm:  "t",
+		Help:       "Location of the component's template file.",
+		IsRequired: true,
+	}
+	registerFile = Flag{
+		LongForm:   "file",
+		ShortForm:  "f",
+		Help:       "Additional file of the component as <loc>:<pattern>:<template>, can be repeated.",
+		IsRequired: false,
+	}
+	registerForce = Flag{
+		LongForm:   "force",
+		Help:       "Replace the registered component with the same name.",
+		IsRequired: false,
+	}
+	registerParam = Flag{
+		LongForm:   "param",
+		ShortForm:  "p",
+		Help:       "Parameter of the component as <name>:<type>[=<default>], can be repeated. Types are string, bool, list and enum(<value>|...).",
+		IsRequired: false,
+	}
+	registerDesc = Flag{
+		LongForm:   "desc",
+		ShortForm:  "d",
+		Help:       "Description of the component.",
+		IsRequired: false,
+	}
+	registerExt = Flag{
+		LongForm:   "ext",
+		Help:       "Extension of the component files, for example .sql or _test.go (default .go).",
+		IsRequired: false,
+	}
+	registerLang = Flag{
+		LongForm: "lang",
+		Help: "Language of the component files, the content is formatted or checked accordingly:\n" +
+			"go, proto, sql, yaml, markdown or text (default derived from the extension).\n",
+		IsRequired: false,
+	}
+	registerNaming = Flag{
+		LongForm: "naming",
+		Help: "Rule names of the component elements follow:\n" +
+			"- ident: Go identifiers (default).\n" +
+			"- kebab: Go identifiers words joined with hyphens.\n" +
+			"- file: safe file names.\n",
+		IsRequired: false,
+	}
 )
 
 func componentsCmd() *cobra.Command {
@@ -41,6 +118,9 @@ func componentsCmd() *cobra.Command {
 
 	cmd.AddCommand(listComponentsCmd())
 	cmd.AddCommand(employComponentCmd())
+	cmd.AddCommand(unemployComponentCmd())
+	cmd.AddCommand(renameComponentCmd())
+	cmd.AddCommand(registerComponentCmd())
 
 	return cmd
 }
@@ -68,28 +148,165 @@ chef components ls`,
 
 func employComponentCmd() *cobra.Command {
 	var inputs struct {
-		Component string // component name
-		Name      string // node name to be created using the component
+		Component string   // component name
+		Name      string   // node name to be created using the component
+		Sets      []string // component parameters values
+		DryRun    bool
 	}
 
 	cmd := &cobra.Command{
 		Use:   "employ",
 		Args:  cobra.NoArgs,
 		Short: "Employ project component",
-		Long:  "Use component to add a new functionality to a project",
+		Long: "Use component to add a new functionality to a project.\n" +
+			"Component parameters are set as key=value, they are available to the component\n" +
+			"templates as .Params.<key>.",
 		Example: `chef components employ --component http_handler --name foo 
-chef components employ -c http_handler -n bar`,
+chef components employ -c http_handler -n bar
+chef components employ -c http_handler -n bar --set method=POST --set path=/v1/bar
+chef components employ -c http_handler -n bar --dry-run`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			p, err := initProject()
 			if err != nil {
 				return err
 			}
-			return componentsEmployCmdRunner(p, inputs.Component, inputs.Name)
+			if inputs.DryRun {
+				return componentsEmployPlanCmdRunner(p, inputs.Component, inputs.Name, inputs.Sets)
+			}
+			return componentsEmployCmdRunner(p, inputs.Component, inputs.Name, inputs.Sets)
 		},
 	}
 
 	component.RegisterString(cmd, &inputs.Component, "")
 	componentName.RegisterString(cmd, &inputs.Name, "")
+	componentSet.RegisterStringArray(cmd, &inputs.Sets, nil)
+	dryRun.RegisterBool(cmd, &inputs.DryRun, false)
+
+	return cmd
+}
+
+func unemployComponentCmd() *cobra.Command {
+	var inputs struct {
+		Component string // component name
+		Name      string // employed component instance name
+		Force     bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unemploy",
+		Args:  cobra.NoArgs,
+		Short: "Unemploy project component",
+		Long: "Remove the files created by employing the component from the project and its notation.\n" +
+			"Files modified since they were generated are not removed unless --force is given.",
+		Example: `chef components unemploy --component http_handler --name health
+chef components unemploy -c http_handler -n health --force`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			return componentsUnemployCmdRunner(p, inputs.Component, inputs.Name, inputs.Force)
+		},
+	}
+
+	component.RegisterString(cmd, &inputs.Component, "")
+	unemployName.RegisterString(cmd, &inputs.Name, "")
+	force.RegisterBool(cmd, &inputs.Force, false)
+
+	return cmd
+}
+
+func renameComponentCmd() *cobra.Command {
+	var inputs struct {
+		Component string // component name
+		Name      string // employed component instance name
+		To        string // new name of the instance
+		Force     bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rename",
+		Args:  cobra.NoArgs,
+		Short: "Rename employed project component",
+		Long: "Rename the files created by employing the component. Files are generated again with\n" +
+			"the new name. Files modified since they were generated are refused unless --force\n" +
+			"is given, then they are moved as is.",
+		Example: `chef components rename --component http_handler --name health --to status
+chef components rename -c http_handler -n health --to status --force`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			return componentsRenameCmdRunner(p, inputs.Component, inputs.Name, inputs.To, inputs.Force)
+		},
+	}
+
+	component.RegisterString(cmd, &inputs.Component, "")
+	unemployName.RegisterString(cmd, &inputs.Name, "")
+	renameTo.RegisterString(cmd, &inputs.To, "")
+	force.RegisterBool(cmd, &inputs.Force, false)
+
+	return cmd
+}
+
+func registerComponentCmd() *cobra.Command {
+	var inputs struct {
+		Name     string   // component name
+		Loc      string   // component location in the project layout
+		Template string   // component template file location
+		Desc     string   // component description
+		Ext      string   // extension of the component files
+		Lang     string   // language of the component files
+		Naming   string   // naming rule of the component elements
+		Files    []string // additional files of the component
+		Params   []string // parameters of the component
+		Force    bool     // replace the registered component
+	}
+
+	cmd := &cobra.Command{
+		Use:   "register",
+		Args:  cobra.NoArgs,
+		Short: "Register project component",
+		Long: "Register a custom project component. The component is stored in the project notation\n" +
+			"and can be employed in later invocations. Additional files of the component are created\n" +
+			"together with the main file, their name pattern is a template executed with the\n" +
+			"component data, for example {{.Name}}_test.go.",
+		Example: `chef components register --name repo --loc provider --template ./tmpl/repo.tmpl
+chef components register -n repo -l provider -t ./tmpl/repo.tmpl -d "Repository provider"
+chef components register -n repo -l provider -t ./tmpl/repo.tmpl -f "test:{{.Name}}_test.go:./tmpl/repo_test.tmpl"
+chef components register -n repo -l provider -t ./tmpl/repo.tmpl -p "db:enum(postgres|mysql)=postgres" -p cache:bool
+chef components register -n page -l web -t ./tmpl/page.tmpl --naming kebab
+chef components register -n migration -l db -t ./tmpl/migration.tmpl --ext .up.sql --naming file
+chef components register -n http_handler -l handler/http -t ./tmpl/handler.tmpl --force`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			c := chef.Component{
+				Name:   inputs.Name,
+				Loc:    inputs.Loc,
+				File:   inputs.Template,
+				Desc:   inputs.Desc,
+				Ext:    inputs.Ext,
+				Lang:   inputs.Lang,
+				Naming: inputs.Naming,
+			}
+			return componentsRegisterCmdRunner(p, c, inputs.Files, inputs.Params, inputs.Force)
+		},
+	}
+
+	registerName.RegisterString(cmd, &inputs.Name, "")
+	registerLoc.RegisterString(cmd, &inputs.Loc, "")
+	registerTemplate.RegisterString(cmd, &inputs.Template, "")
+	registerDesc.RegisterString(cmd, &inputs.Desc, "")
+	registerExt.RegisterString(cmd, &inputs.Ext, "")
+	registerLang.RegisterString(cmd, &inputs.Lang, "")
+	registerNaming.RegisterString(cmd, &inputs.Naming, "")
+	registerFile.RegisterStringArray(cmd, &inputs.Files, nil)
+	registerParam.RegisterStringArray(cmd, &inputs.Params, nil)
+	registerForce.RegisterBool(cmd, &inputs.Force, false)
 
 	return cmd
 }
@@ -99,32 +316,194 @@ func componentsListCmdRunner(p Project) error {
 		return errors.Wrap(err, "init project failed")
 	}
 
+	if output.Structured() {
+		return display.Document(printout, output, display.NewComponentsDocument(p.Components()))
+	}
 	return display.ComponentsList(printout, p.Components())
 }
 
-func componentsEmployCmdRunner(p Project, component, name string) error {
+func componentsEmployCmdRunner(p Project, component, name string, sets []string) error {
 	if err := p.Init(); err != nil {
 		return errors.Wrap(err, "init project failed")
 	}
 
-	if err := p.EmployComponent(component, name); err != nil {
+	values, err := componentValues(sets)
+	if err != nil {
+		return err
+	}
+
+	created, err := p.EmployComponent(component, name, values)
+	if err != nil {
 		// TODO: better explanation why employ failed
 		return errors.Wrapf(err, "employ %q component failed", component)
 	}
 
+	if output.Structured() {
+		return display.Document(printout, output, display.NewEmployDocument(p.Loc(), name, component, created))
+	}
 	return display.ComponentsEmploy(printout, name, component)
 }
 
+func componentsEmployPlanCmdRunner(p Project, component, name string, sets []string) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	values, err := componentValues(sets)
+	if err != nil {
+		return err
+	}
+
+	entries, err := p.PlanComponent(component, name, values)
+	if err != nil {
+		return errors.Wrapf(err, "employ %q component failed", component)
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewPlanDocument(entries))
+	}
+	return display.Plan(printout, entries)
+}
+
+func componentsUnemployCmdRunner(p Project, component, name string, force bool) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	removed, err := p.UnemployComponent(component, name, force)
+	if err != nil {
+		return errors.Wrapf(err, "unemploy %q component failed", component)
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewUnemployDocument(p.Loc(), name, component, removed))
+	}
+	return display.ComponentsUnemploy(printout, name, component)
+}
+
+func componentsRenameCmdRunner(p Project, component, name, to string, force bool) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	renamed, err := p.RenameComponent(component, name, to, force)
+	if err != nil {
+		return errors.Wrapf(err, "rename %q component failed", component)
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewRenameDocument(p.Loc(), component, name, to, renamed))
+	}
+	return display.ComponentsRename(printout, name, to, component)
+}
+
+func componentsRegisterCmdRunner(p Project, c chef.Component, files, params []string, force bool) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	// template location provided relative to the working directory
+	tloc, err := filepath.Abs(c.File)
+	if err != nil {
+		return errors.Wrap(err, "failed to get template location")
+	}
+
+	outputs, err := componentOutputs(files)
+	if err != nil {
+		return err
+	}
+
+	prms, err := componentParams(params)
+	if err != nil {
+		return err
+	}
+
+	c.File = tloc
+	c.Outputs = outputs
+	c.Params = prms
+	if err := p.AddComponent(c, force); err != nil {
+		return errors.Wrapf(err, "register %q component failed", c.Name)
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.RegisterDocument{Component: c.Name})
+	}
+	return display.ComponentsRegister(printout, c.Name)
+}
+
+// componentOutputs parses additional files of the component provided as
+// <loc>:<pattern>:<template>.
+func componentOutputs(files []string) ([]chef.Output, error) {
+	outputs := make([]chef.Output, 0, len(files))
+	for _, f := range files {
+		parts := strings.SplitN(f, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, errors.Errorf("invalid component file %q, expected <loc>:<pattern>:<template>", f)
+		}
+
+		tloc, err := filepath.Abs(parts[2])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get template location")
+		}
+		outputs = append(outputs, chef.Output{Loc: parts[0], Pattern: parts[1], File: tloc})
+	}
+	return outputs, nil
+}
+
+// componentParams parses parameters of the component provided as
+// <name>:<type>[=<default>], where enum type lists its values as
+// enum(<value>|...).
+func componentParams(params []string) ([]chef.Param, error) {
+	prms := make([]chef.Param, 0, len(params))
+	for _, s := range params {
+		decl, def, _ := strings.Cut(s, "=")
+		name, typ, ok := strings.Cut(decl, ":")
+		if !ok || name == "" || typ == "" {
+			return nil, errors.Errorf("invalid component parameter %q, expected <name>:<type>[=<default>]", s)
+		}
+
+		prm := chef.Param{Name: name, Type: typ, Default: def}
+		if values, ok := strings.CutPrefix(typ, "enum("); ok {
+			values, ok = strings.CutSuffix(values, ")")
+			if !ok {
+				return nil, errors.Errorf("invalid component parameter %q, expected enum(<value>|...)", s)
+			}
+			prm.Type = "enum"
+			prm.Values = strings.Split(values, "|")
+		}
+		prms = append(prms, prm)
+	}
+	return prms, nil
+}
+
+// componentValues parses values of the component parameters provided as
+// <key>=<value>.
+func componentValues(sets []string) (map[string]string, error) {
+	values := make(map[string]string, len(sets))
+	for _, s := range sets {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || key == "" {
+			return nil, errors.Errorf("invalid component parameter value %q, expected <key>=<value>", s)
+		}
+		if _, ok := values[key]; ok {
+			return nil, errors.Errorf("duplicate component parameter %q", key)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
 func initProject() (*project.Project, error) {
-	dir, err := os.Getwd()
+	dir, err := projectRoot()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get working directory")
+		return nil, err
 	}
 
 	f, err := os.Open(path.Join(dir, chef.DefaultNotationFileName))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open notation")
 	}
+	defer f.Close()
 
 	n, err := chef.ReadNotation(f)
 	if err != nil {
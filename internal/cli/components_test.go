@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/project"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestComponentsListCmdRunner(t *testing.T) {
@@ -42,13 +45,19 @@ func TestComponentsListCmdRunner(t *testing.T) {
 func TestComponentsEmployCmdRunner(t *testing.T) {
 	t.Run("fails when project init failed", func(t *testing.T) {
 		p := FailedInit(errors.New("some init error"))
-		err := componentsEmployCmdRunner(p, "handler", "health")
+		err := componentsEmployCmdRunner(p, "handler", "health", nil)
 		assert.EqualError(t, err, "init project failed: some init error")
 	})
 
+	t.Run("fails when component parameters values are invalid", func(t *testing.T) {
+		p := projMock{}
+		err := componentsEmployCmdRunner(p, "handler", "health", []string{"method"})
+		assert.EqualError(t, err, `invalid component parameter value "method", expected <key>=<value>`)
+	})
+
 	t.Run("fails when employ component failed", func(t *testing.T) {
 		p := FailedEmployComponent(errors.New("some employ component error"))
-		err := componentsEmployCmdRunner(p, "handler", "health")
+		err := componentsEmployCmdRunner(p, "handler", "health", nil)
 		assert.EqualError(t, err, `employ "handler" component failed: some employ component error`)
 	})
 
@@ -57,18 +66,211 @@ func TestComponentsEmployCmdRunner(t *testing.T) {
 		printout = &buf
 
 		p := projMock{}
-		err := componentsEmployCmdRunner(p, "handler", "health")
+		err := componentsEmployCmdRunner(p, "handler", "health", nil)
 		assert.NoError(t, err)
 
 		assert.Equal(t, "successfully added \"health\" as \"handler\" component\n", buf.String())
 	})
 }
 
+func TestComponentsEmployPlanCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := componentsEmployPlanCmdRunner(p, "handler", "health", nil)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when plan component failed", func(t *testing.T) {
+		p := FailedPlan(errors.New("some plan error"))
+		err := componentsEmployPlanCmdRunner(p, "handler", "health", nil)
+		assert.EqualError(t, err, `employ "handler" component failed: some plan error`)
+	})
+
+	t.Run("shows planned component entries", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{entries: []fsys.Entry{{Path: "/tmp/project/handler/health.go", Perm: 0644, Size: 10}}}
+		err := componentsEmployPlanCmdRunner(p, "handler", "health", nil)
+		assert.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "/tmp/project/handler/health.go\n")
+	})
+}
+
+func TestComponentsUnemployCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := componentsUnemployCmdRunner(p, "handler", "health", false)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when unemploy component failed", func(t *testing.T) {
+		p := FailedUnemployComponent(errors.New("some unemploy component error"))
+		err := componentsUnemployCmdRunner(p, "handler", "health", false)
+		assert.EqualError(t, err, `unemploy "handler" component failed: some unemploy component error`)
+	})
+
+	t.Run("successfully unemploys a component", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{}
+		err := componentsUnemployCmdRunner(p, "handler", "health", false)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "successfully removed \"health\" \"handler\" component\n", buf.String())
+	})
+}
+
+func TestComponentsRenameCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := componentsRenameCmdRunner(p, "handler", "health", "status", false)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when rename component failed", func(t *testing.T) {
+		p := FailedRenameComponent(errors.New("some rename component error"))
+		err := componentsRenameCmdRunner(p, "handler", "health", "status", false)
+		assert.EqualError(t, err, `rename "handler" component failed: some rename component error`)
+	})
+
+	t.Run("successfully renames a component", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{}
+		err := componentsRenameCmdRunner(p, "handler", "health", "status", false)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "successfully renamed \"health\" \"handler\" component to \"status\"\n", buf.String())
+	})
+}
+
+func TestComponentsRegisterCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := componentsRegisterCmdRunner(p, chef.Component{Name: "repo", Loc: "provider", File: "repo.tmpl"}, nil, nil, false)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when add component failed", func(t *testing.T) {
+		p := FailedAddComponent(errors.New("some add component error"))
+		err := componentsRegisterCmdRunner(p, chef.Component{Name: "repo", Loc: "provider", File: "repo.tmpl"}, nil, nil, false)
+		assert.EqualError(t, err, `register "repo" component failed: some add component error`)
+	})
+
+	t.Run("successfully registers a component", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{}
+		err := componentsRegisterCmdRunner(p, chef.Component{Name: "repo", Loc: "provider", File: "repo.tmpl", Desc: "Repository"}, nil, nil, false)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "successfully registered \"repo\" component\n", buf.String())
+	})
+}
+
+func TestComponentOutputs(t *testing.T) {
+	t.Run("fails when file is invalid", func(t *testing.T) {
+		_, err := componentOutputs([]string{"test:{{.Name}}_test.go"})
+		assert.EqualError(t, err, `invalid component file "test:{{.Name}}_test.go", expected <loc>:<pattern>:<template>`)
+	})
+
+	t.Run("parses component files", func(t *testing.T) {
+		outputs, err := componentOutputs([]string{"test:{{.Name}}_test.go:/tmp/repo_test.tmpl"})
+		require.NoError(t, err)
+		expected := []chef.Output{{Loc: "test", Pattern: "{{.Name}}_test.go", File: "/tmp/repo_test.tmpl"}}
+		assert.Equal(t, expected, outputs)
+	})
+}
+
+func TestComponentParams(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		params []string
+		err    string
+	}{
+		{
+			desc:   "when type is missing",
+			params: []string{"method"},
+			err:    `invalid component parameter "method", expected <name>:<type>[=<default>]`,
+		},
+		{
+			desc:   "when name is empty",
+			params: []string{":string"},
+			err:    `invalid component parameter ":string", expected <name>:<type>[=<default>]`,
+		},
+		{
+			desc:   "when enum values are not closed",
+			params: []string{"method:enum(GET|POST=GET"},
+			err:    `invalid component parameter "method:enum(GET|POST=GET", expected enum(<value>|...)`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run("fails "+tC.desc, func(t *testing.T) {
+			_, err := componentParams(tC.params)
+			assert.EqualError(t, err, tC.err)
+		})
+	}
+
+	t.Run("parses component parameters", func(t *testing.T) {
+		params, err := componentParams([]string{"method:enum(GET|POST)=POST", "tags:list", "auth:bool=true"})
+		require.NoError(t, err)
+		expected := []chef.Param{
+			{Name: "method", Type: "enum", Default: "POST", Values: []string{"GET", "POST"}},
+			{Name: "tags", Type: "list"},
+			{Name: "auth", Type: "bool", Default: "true"},
+		}
+		assert.Equal(t, expected, params)
+	})
+}
+
+func TestComponentValues(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sets []string
+		err  string
+	}{
+		{
+			desc: "when value is missing",
+			sets: []string{"method"},
+			err:  `invalid component parameter value "method", expected <key>=<value>`,
+		},
+		{
+			desc: "when key is empty",
+			sets: []string{"=GET"},
+			err:  `invalid component parameter value "=GET", expected <key>=<value>`,
+		},
+		{
+			desc: "when key is duplicated",
+			sets: []string{"method=GET", "method=POST"},
+			err:  `duplicate component parameter "method"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run("fails "+tC.desc, func(t *testing.T) {
+			_, err := componentValues(tC.sets)
+			assert.EqualError(t, err, tC.err)
+		})
+	}
+
+	t.Run("parses component parameters values", func(t *testing.T) {
+		values, err := componentValues([]string{"method=POST", "path=/v1/a=b", "tags="})
+		require.NoError(t, err)
+		expected := map[string]string{"method": "POST", "path": "/v1/a=b", "tags": ""}
+		assert.Equal(t, expected, values)
+	})
+}
+
 func TestInitProjectFails(t *testing.T) {
-	t.Run("when no notation file found in working directory", func(t *testing.T) {
+	t.Run("when no notation file found in working directory and its parents", func(t *testing.T) {
 		p, err := initProject()
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to open notation:")
+		assert.Contains(t, err.Error(), "failed to find project from")
+		assert.ErrorIs(t, err, chef.ErrNotationNotFound)
 		assert.Nil(t, p)
 	})
 
@@ -85,3 +287,33 @@ func TestInitProjectFails(t *testing.T) {
 		assert.Nil(t, p)
 	})
 }
+
+func TestInitProject(t *testing.T) {
+	t.Cleanup(func() {
+		globals.Project = ""
+		globals.Verbose = false
+	})
+
+	root := t.TempDir()
+	loc := path.Join(root, "project")
+	nested := path.Join(loc, "handler", "http")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	notation := "version: unknown\nschema: 2\ncategory: srv\nserver: http\n"
+	require.NoError(t, os.WriteFile(path.Join(loc, chef.DefaultNotationFileName), []byte(notation), 0644))
+
+	var buf bytes.Buffer
+	printout = &buf
+	globals.Project = nested
+	globals.Verbose = true
+
+	p, err := initProject()
+	require.NoError(t, err)
+	require.NoError(t, p.Init())
+	assert.Equal(t, "project root: "+loc+"\n", buf.String())
+
+	entries, err := p.PlanComponent("http_handler", "echo", nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, path.Join(nested, "echo.go"), entries[0].Path)
+	assert.Equal(t, path.Join(nested, "echo_test.go"), entries[1].Path)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"github.com/antklim/chef/internal/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func doctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Args:  cobra.NoArgs,
+		Short: "Check project against its layout",
+		Long: "Check the project on disk against the layout reconstructed from the notation.\n" +
+			"Reports missing nodes, unexpected entries in the layout directories, permission\n" +
+			"mismatches and files which content differs from a fresh render. Exits with\n" +
+			"non-zero status when problems found.",
+		Example: `chef doctor
+chef doctor --project ./myproject`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			return doctorCmdRunner(p)
+		},
+	}
+
+	return cmd
+}
+
+func doctorCmdRunner(p Project) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	findings, err := p.Doctor()
+	if err != nil {
+		return errors.Wrap(err, "project doctor failed")
+	}
+
+	if output.Structured() {
+		err = display.Document(printout, output, display.NewDoctorDocument(p.Loc(), findings))
+	} else {
+		err = display.Doctor(printout, p.Loc(), findings)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(findings) > 0 {
+		return errors.Errorf("project doctor found %d problem(s)", len(findings))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := doctorCmdRunner(p)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project doctor failed", func(t *testing.T) {
+		p := FailedDoctor(errors.New("some doctor error"))
+		err := doctorCmdRunner(p)
+		assert.EqualError(t, err, "project doctor failed: some doctor error")
+	})
+
+	t.Run("fails when problems found", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{
+			loc:      "/tmp/cheftest",
+			findings: []project.Finding{{Path: "main.go", Problem: "content differs"}},
+		}
+		err := doctorCmdRunner(p)
+		assert.EqualError(t, err, "project doctor found 1 problem(s)")
+		assert.Contains(t, buf.String(), "main.go\tcontent differs\n")
+	})
+
+	t.Run("succeeds when no problems found", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{loc: "/tmp/cheftest"}
+		err := doctorCmdRunner(p)
+		assert.NoError(t, err)
+		assert.Equal(t, "project doctor at /tmp/cheftest:\n\tno problems found\n", buf.String())
+	})
+}
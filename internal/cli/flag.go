@@ -18,6 +18,22 @@ func (f *Flag) RegisterString(cmd *cobra.Command, value *string, defaultValue st
 	registerString(cmd, f, value, defaultValue)
 }
 
+func (f *Flag) RegisterBool(cmd *cobra.Command, value *bool, defaultValue bool) {
+	registerBool(cmd, f, value, defaultValue)
+}
+
+func (f *Flag) RegisterStringArray(cmd *cobra.Command, value *[]string, defaultValue []string) {
+	registerStringArray(cmd, f, value, defaultValue)
+}
+
+func (f *Flag) RegisterPersistentString(cmd *cobra.Command, value *string, defaultValue string) {
+	cmd.PersistentFlags().StringVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+}
+
+func (f *Flag) RegisterPersistentBool(cmd *cobra.Command, value *bool, defaultValue bool) {
+	cmd.PersistentFlags().BoolVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+}
+
 func registerString(cmd *cobra.Command, f *Flag, value *string, defaultValue string) {
 	cmd.Flags().StringVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
 
@@ -26,6 +42,22 @@ func registerString(cmd *cobra.Command, f *Flag, value *string, defaultValue str
 	}
 }
 
+func registerStringArray(cmd *cobra.Command, f *Flag, value *[]string, defaultValue []string) {
+	cmd.Flags().StringArrayVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+
+	if err := markFlagRequired(cmd, f); err != nil {
+		panic(errors.Wrap(err, "failed to register string array flag"))
+	}
+}
+
+func registerBool(cmd *cobra.Command, f *Flag, value *bool, defaultValue bool) {
+	cmd.Flags().BoolVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+
+	if err := markFlagRequired(cmd, f); err != nil {
+		panic(errors.Wrap(err, "failed to register bool flag"))
+	}
+}
+
 func markFlagRequired(cmd *cobra.Command, f *Flag) error {
 	if f.IsRequired {
 		return cmd.MarkFlagRequired(f.LongForm)
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/project"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -24,7 +28,9 @@ var (
 		LongForm:  "category",
 		ShortForm: "c",
 		Help: "Category of project:\n" +
-			"- srv: service application based on HTTP or gRPC.\n",
+			"- srv: service application based on HTTP or gRPC.\n" +
+			"- pkg: reusable library package.\n" +
+			"- cli: command line application based on cobra.\n",
 		IsRequired: true,
 	}
 	projModule = Flag{
@@ -33,29 +39,49 @@ var (
 		Help:       "Name of the project's module to be used in 'go mod'.",
 		IsRequired: true,
 	}
-	// This flag is a location of chef.yml with the project layout configuration
+	// This flag is a location of YAML file with the project layout definition
 	projLayout = Flag{
 		LongForm:   "layout",
 		ShortForm:  "l",
-		Help:       "Location of the project's layout configuration.",
+		Help:       "Location of the project's layout configuration (YAML).",
+		IsRequired: false,
+	}
+	projTemplates = Flag{
+		LongForm:   "templates",
+		ShortForm:  "t",
+		Help:       "Location of the directory with user templates (*.tmpl).",
+		IsRequired: false,
+	}
+	projGoVersion = Flag{
+		LongForm:   "go",
+		Help:       "Go version to be used in 'go.mod'.",
+		IsRequired: false,
+	}
+	projVerify = Flag{
+		LongForm:   "verify",
+		Help:       "Run 'go build' and 'go vet' on the initialized project.",
 		IsRequired: false,
 	}
 	projServer = Flag{
 		LongForm:   "server",
 		ShortForm:  "s",
-		Help:       "Server type for projects of category service.",
+		Help:       "Server type for projects of category service: http, grpc.",
 		IsRequired: false,
 	}
 )
 
 func initCmd() *cobra.Command {
 	var inputs struct {
-		Name     string
-		Root     string
-		Category string
-		Module   string
-		Layout   string
-		Server   string
+		Name      string
+		Root      string
+		Category  string
+		Module    string
+		Layout    string
+		Server    string
+		Templates string
+		GoVersion string
+		DryRun    bool
+		Verify    bool
 	}
 
 	cmd := &cobra.Command{
@@ -65,15 +91,49 @@ func initCmd() *cobra.Command {
 		Long:  "initialize a new project",
 		Example: `chef init --name myproject
 chef init --category [srv] --name myproject
-chef init -c [srv] -n myproject --root /usr/local`,
+chef init -c [srv|pkg|cli] -n myproject --root /usr/local
+chef init -c [srv] -n myproject --layout ./layout.yml
+chef init -c [srv] -n myproject --templates ./templates --layout ./layout.yml
+chef init -c [srv] -n myproject --dry-run
+chef init -c [srv] -n myproject -m github.com/me/myproject --go 1.23 --verify`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			p := project.New(inputs.Name,
+			if inputs.DryRun && inputs.Verify {
+				return errors.New("--verify cannot be used with --dry-run")
+			}
+			opts := []project.Option{
 				project.WithRoot(inputs.Root),
 				project.WithCategory(inputs.Category),
 				project.WithServer(inputs.Server),
 				project.WithModule(inputs.Module),
-			)
-			return initCmdRunner(p)
+				project.WithGoVersion(inputs.GoVersion),
+			}
+			if inputs.Templates != "" {
+				tmpls, err := templatesLocation(inputs.Templates)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, project.WithTemplates(tmpls))
+			}
+			if inputs.Layout != "" {
+				// Layout created from the definition by the project after it
+				// loads templates, to make them referenceable from the layout.
+				def, err := readLayout(inputs.Layout)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, project.WithLayoutDefinition(def))
+			}
+			p := project.New(inputs.Name, opts...)
+			if inputs.DryRun {
+				return initPlanCmdRunner(p)
+			}
+			if err := initCmdRunner(p); err != nil {
+				return err
+			}
+			if inputs.Verify {
+				return initVerifyCmdRunner(p)
+			}
+			return nil
 		},
 	}
 
@@ -83,6 +143,10 @@ chef init -c [srv] -n myproject --root /usr/local`,
 	projModule.RegisterString(cmd, &inputs.Module, "")
 	projLayout.RegisterString(cmd, &inputs.Layout, "")
 	projServer.RegisterString(cmd, &inputs.Server, "")
+	projTemplates.RegisterString(cmd, &inputs.Templates, "")
+	projGoVersion.RegisterString(cmd, &inputs.GoVersion, "")
+	dryRun.RegisterBool(cmd, &inputs.DryRun, false)
+	projVerify.RegisterBool(cmd, &inputs.Verify, false)
 
 	return cmd
 }
@@ -97,5 +161,60 @@ func initCmdRunner(p Project) error {
 		return errors.Wrap(err, "init project failed")
 	}
 
-	return display.ProjectInit(printout, loc, p.Components())
+	if output.Structured() {
+		return display.Document(printout, output, display.NewInitDocument(loc, p.Paths(), p.Components()))
+	}
+	return display.ProjectInit(printout, loc, p.Components(), p.Dependencies())
+}
+
+func initVerifyCmdRunner(p Project) error {
+	if err := p.Verify(); err != nil {
+		return errors.Wrap(err, "verify project failed")
+	}
+
+	// the init document is the only output of init command, verification
+	// failures are reported as errors
+	if output.Structured() {
+		return nil
+	}
+	return display.ProjectVerify(printout)
+}
+
+func initPlanCmdRunner(p Project) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	entries, err := p.Plan()
+	if err != nil {
+		return errors.Wrap(err, "plan project failed")
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewPlanDocument(entries))
+	}
+	return display.Plan(printout, entries)
+}
+
+func readLayout(loc string) (layout.Definition, error) {
+	f, err := os.Open(loc)
+	if err != nil {
+		return layout.Definition{}, errors.Wrap(err, "failed to open layout")
+	}
+	defer f.Close()
+
+	def, err := layout.ReadDefinition(f)
+	if err != nil {
+		return layout.Definition{}, errors.Wrapf(err, "failed to read layout %s", loc)
+	}
+	return def, nil
+}
+
+// templatesLocation returns absolute location of user templates.
+func templatesLocation(loc string) (string, error) {
+	aloc, err := filepath.Abs(loc)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get templates location")
+	}
+	return aloc, nil
 }
@@ -3,11 +3,73 @@ package cli
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path"
 	"testing"
 
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/project/template"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestInitCmd(t *testing.T) {
+	t.Run("fails when verify used with dry run", func(t *testing.T) {
+		cmd := initCmd()
+		cmd.SetArgs([]string{"--name", "cheftest", "--category", "srv", "--module", "cheftest", "--root", t.TempDir(), "--dry-run", "--verify"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		assert.EqualError(t, err, "--verify cannot be used with --dry-run")
+	})
+
+	t.Run("fails when layout references unknown template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		loc := path.Join(tmpDir, "layout.yml")
+		err := os.WriteFile(loc, []byte("nodes:\n  - file: main.go\n    template: foo\n"), 0600)
+		require.NoError(t, err)
+
+		cmd := initCmd()
+		cmd.SetArgs([]string{"--name", "cheftest", "--category", "srv", "--module", "cheftest", "--root", tmpDir, "--layout", loc})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err = cmd.Execute()
+		assert.ErrorContains(t, err, `line 2: file "main.go": unknown template "foo"`)
+	})
+
+	t.Run("creates layout referencing user templates", func(t *testing.T) {
+		template.KeepRegistered(t)
+		var buf bytes.Buffer
+		printout = &buf
+
+		tmpDir := t.TempDir()
+		tmplDir := path.Join(tmpDir, "tmpl")
+		err := os.Mkdir(tmplDir, 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(tmplDir, "greet.tmpl"), []byte("package main // {{ .Module }}"), 0600)
+		require.NoError(t, err)
+		loc := path.Join(tmpDir, "layout.yml")
+		err = os.WriteFile(loc, []byte("nodes:\n  - file: main.go\n    template: greet\n  - file: README.md\n    body: \"# {{ .Module | base }}\"\n"), 0600)
+		require.NoError(t, err)
+
+		cmd := initCmd()
+		cmd.SetArgs([]string{"--name", "cheftest", "--category", "srv", "--module", "example.com/cheftest", "--root", tmpDir,
+			"--layout", loc, "--templates", tmplDir})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(tmpDir, "cheftest", "main.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package main // example.com/cheftest\n", string(data))
+
+		data, err = os.ReadFile(path.Join(tmpDir, "cheftest", "README.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# cheftest", string(data))
+	})
+}
+
 func TestInitCmdRunner(t *testing.T) {
 	t.Run("fails when project init failed", func(t *testing.T) {
 		p := FailedInit(errors.New("some init error"))
@@ -31,4 +93,102 @@ func TestInitCmdRunner(t *testing.T) {
 
 		assert.Contains(t, buf.String(), "project successfully inited at project_location\n")
 	})
+
+	t.Run("shows how to fetch project dependencies", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{loc: "project_location", deps: []string{"github.com/spf13/cobra"}}
+		err := initCmdRunner(p)
+		assert.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "go.mod requires github.com/spf13/cobra, run `go mod tidy` in the project to fetch them\n")
+	})
+}
+
+func TestInitVerifyCmdRunner(t *testing.T) {
+	t.Run("fails when project verification failed", func(t *testing.T) {
+		p := FailedVerify(errors.New("some verify error"))
+		err := initVerifyCmdRunner(p)
+		assert.EqualError(t, err, "verify project failed: some verify error")
+	})
+
+	t.Run("successfully verifies a project", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		err := initVerifyCmdRunner(projMock{})
+		assert.NoError(t, err)
+		assert.Equal(t, "project successfully verified\n", buf.String())
+	})
+}
+
+func TestInitPlanCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := initPlanCmdRunner(p)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project plan failed", func(t *testing.T) {
+		p := FailedPlan(errors.New("some plan error"))
+		err := initPlanCmdRunner(p)
+		assert.EqualError(t, err, "plan project failed: some plan error")
+	})
+
+	t.Run("shows planned project entries", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{entries: []fsys.Entry{
+			{Path: "/tmp/project", Perm: 0755, Dir: true},
+			{Path: "/tmp/project/main.go", Perm: 0644, Size: 10},
+		}}
+		err := initPlanCmdRunner(p)
+		assert.NoError(t, err)
+
+		bufs := buf.String()
+		assert.Contains(t, bufs, "dry run, planned changes:\n")
+		assert.Contains(t, bufs, "/tmp/project/\n")
+		assert.Contains(t, bufs, "  main.go\n")
+	})
+}
+
+func TestReadLayout(t *testing.T) {
+	t.Run("fails when layout file does not exist", func(t *testing.T) {
+		_, err := readLayout("foo.yml")
+		assert.EqualError(t, err, "failed to open layout: open foo.yml: no such file or directory")
+	})
+
+	t.Run("fails when layout definition is invalid", func(t *testing.T) {
+		loc := path.Join(t.TempDir(), "layout.yml")
+		err := os.WriteFile(loc, []byte("nodes:\n  - file: main.go\n    tmpl: foo\n"), 0600)
+		require.NoError(t, err)
+
+		_, err = readLayout(loc)
+		assert.ErrorContains(t, err, "failed to read layout "+loc+": failed to decode layout definition")
+	})
+
+	t.Run("reads layout definition without resolving templates", func(t *testing.T) {
+		loc := path.Join(t.TempDir(), "layout.yml")
+		err := os.WriteFile(loc, []byte("nodes:\n  - file: main.go\n    template: foo\n"), 0600)
+		require.NoError(t, err)
+
+		def, err := readLayout(loc)
+		require.NoError(t, err)
+		require.Len(t, def.Nodes, 1)
+		assert.Equal(t, "main.go", def.Nodes[0].File)
+		assert.Equal(t, "foo", def.Nodes[0].Template)
+	})
+}
+
+func TestTemplatesLocation(t *testing.T) {
+	t.Run("returns absolute templates location", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		loc, err := templatesLocation("tmpl")
+		assert.NoError(t, err)
+		assert.Equal(t, path.Join(wd, "tmpl"), loc)
+	})
 }
@@ -1,13 +1,33 @@
 package cli
 
-import "github.com/antklim/chef/internal/project"
+import (
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/project"
+)
 
 type projMock struct {
 	initErr    error
 	buildErr   error
 	ecErr      error
+	ucErr      error
+	rcErr      error
+	acErr      error
+	planErr    error
+	verifyErr  error
+	inspectErr error
+	doctorErr  error
+	syncErr    error
+	upgradeErr error
 	loc        string
 	components []project.Component
+	entries    []fsys.Entry
+	nodes      []project.NodeInfo
+	findings   []project.Finding
+	created    []string
+	deps       []string
+	report     project.SyncReport
+	upgrade    project.UpgradeReport
 }
 
 func (p projMock) Init() error {
@@ -22,8 +42,64 @@ func (p projMock) Components() []project.Component {
 	return p.components
 }
 
-func (p projMock) EmployComponent(_, _ string) error {
-	return p.ecErr
+func (p projMock) EmployComponent(_, _ string, _ map[string]string) ([]string, error) {
+	return p.created, p.ecErr
+}
+
+func (p projMock) UnemployComponent(_, _ string, _ bool) ([]string, error) {
+	return p.created, p.ucErr
+}
+
+func (p projMock) RenameComponent(_, _, _ string, _ bool) ([]string, error) {
+	return p.created, p.rcErr
+}
+
+func (p projMock) AddComponent(_ chef.Component, _ bool) error {
+	return p.acErr
+}
+
+func (p projMock) Plan() ([]fsys.Entry, error) {
+	return p.entries, p.planErr
+}
+
+func (p projMock) PlanComponent(_, _ string, _ map[string]string) ([]fsys.Entry, error) {
+	return p.entries, p.planErr
+}
+
+func (p projMock) Verify() error {
+	return p.verifyErr
+}
+
+func (p projMock) Inspect() ([]project.NodeInfo, error) {
+	return p.nodes, p.inspectErr
+}
+
+func (p projMock) Doctor() ([]project.Finding, error) {
+	return p.findings, p.doctorErr
+}
+
+func (p projMock) Sync(_ bool) (project.SyncReport, error) {
+	return p.report, p.syncErr
+}
+
+func (p projMock) PlanSync(_ bool) ([]fsys.Entry, error) {
+	return p.entries, p.planErr
+}
+
+func (p projMock) Upgrade() (project.UpgradeReport, error) {
+	return p.upgrade, p.upgradeErr
+}
+
+func (p projMock) Paths() []string {
+	return p.created
+}
+
+func (p projMock) Dependencies() []string {
+	return p.deps
+}
+
+func (p projMock) Loc() string {
+	return p.loc
 }
 
 func FailedInit(err error) Project {
@@ -37,3 +113,39 @@ func FailedBuild(err error) Project {
 func FailedEmployComponent(err error) Project {
 	return projMock{ecErr: err}
 }
+
+func FailedUnemployComponent(err error) Project {
+	return projMock{ucErr: err}
+}
+
+func FailedRenameComponent(err error) Project {
+	return projMock{rcErr: err}
+}
+
+func FailedAddComponent(err error) Project {
+	return projMock{acErr: err}
+}
+
+func FailedPlan(err error) Project {
+	return projMock{planErr: err}
+}
+
+func FailedVerify(err error) Project {
+	return projMock{verifyErr: err}
+}
+
+func FailedInspect(err error) Project {
+	return projMock{inspectErr: err}
+}
+
+func FailedDoctor(err error) Project {
+	return projMock{doctorErr: err}
+}
+
+func FailedSync(err error) Project {
+	return projMock{syncErr: err}
+}
+
+func FailedUpgrade(err error) Project {
+	return projMock{upgradeErr: err}
+}
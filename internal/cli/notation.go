@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"path"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func notationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notation",
+		Short: "Manage project notation",
+		Long:  "Manage project notation stored in " + chef.DefaultNotationFileName,
+	}
+
+	cmd.AddCommand(migrateNotationCmd())
+
+	return cmd
+}
+
+func migrateNotationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Args:  cobra.NoArgs,
+		Short: "Migrate project notation",
+		Long: "Upgrade project notation to the current schema version.\n" +
+			"The original notation is kept next to it with " + chef.BackupExt + " extension,\n" +
+			"migration fails when the backup already exists.",
+		Example: `chef notation migrate`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dir, err := projectRoot()
+			if err != nil {
+				return err
+			}
+			return notationMigrateCmdRunner(path.Join(dir, chef.DefaultNotationFileName))
+		},
+	}
+
+	return cmd
+}
+
+func notationMigrateCmdRunner(file string) error {
+	from, err := chef.MigrateNotation(file)
+	if err != nil {
+		return errors.Wrap(err, "notation migration failed")
+	}
+
+	backup := file + chef.BackupExt
+	if output.Structured() {
+		doc := display.NotationMigrateDocument{From: from, To: chef.SchemaVersion}
+		if from != chef.SchemaVersion {
+			doc.Backup = backup
+		}
+		return display.Document(printout, output, doc)
+	}
+	return display.NotationMigrate(printout, from, chef.SchemaVersion, backup)
+}
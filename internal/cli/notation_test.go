@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotationMigrateCmdRunner(t *testing.T) {
+	t.Run("fails when notation does not exist", func(t *testing.T) {
+		err := notationMigrateCmdRunner(path.Join(t.TempDir(), chef.DefaultNotationFileName))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "notation migration failed:")
+	})
+
+	t.Run("migrates notation", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		file := path.Join(t.TempDir(), chef.DefaultNotationFileName)
+		err := os.WriteFile(file, []byte("version: unknown\ncategory: srv\n"), 0644)
+		require.NoError(t, err)
+
+		err = notationMigrateCmdRunner(file)
+		require.NoError(t, err)
+		assert.Equal(t, "notation migrated from schema version 1 to 2\nbackup saved to "+file+".bak\n", buf.String())
+	})
+}
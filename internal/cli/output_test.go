@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredOutput(t *testing.T) {
+	setFormat := func(t *testing.T, f display.Format) {
+		output = f
+		t.Cleanup(func() { output = display.Text })
+	}
+
+	t.Run("outputs components list", func(t *testing.T) {
+		setFormat(t, display.JSON)
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{components: []project.Component{{Name: "handler", Loc: "handler", Desc: "HTTP handler"}}}
+		err := componentsListCmdRunner(p)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"components": [{"name": "handler", "loc": "handler", "desc": "HTTP handler"}]}`, buf.String())
+	})
+
+	t.Run("outputs inited project", func(t *testing.T) {
+		setFormat(t, display.JSON)
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{loc: "/tmp/cheftest", created: []string{"main.go"}}
+		err := initCmdRunner(p)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"location": "/tmp/cheftest", "created": ["main.go"], "components": []}`, buf.String())
+	})
+
+	t.Run("outputs employed component", func(t *testing.T) {
+		setFormat(t, display.YAML)
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{loc: "/tmp/cheftest", created: []string{"handler/health.go"}}
+		err := componentsEmployCmdRunner(p, "handler", "health", nil)
+		require.NoError(t, err)
+
+		expected := "location: /tmp/cheftest\ncomponent: handler\nname: health\ncreated:\n  - handler/health.go\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestSetOutput(t *testing.T) {
+	t.Cleanup(func() {
+		globals.Output = ""
+		output = display.Text
+	})
+
+	globals.Output = "yaml"
+	err := setOutput()
+	require.NoError(t, err)
+	assert.Equal(t, display.YAML, output)
+
+	globals.Output = "xml"
+	err = setOutput()
+	assert.EqualError(t, err, `unknown output format "xml", expected text, json or yaml`)
+}
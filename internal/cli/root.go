@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/antklim/chef/internal/display"
 	"github.com/spf13/cobra"
 )
 
@@ -11,7 +12,21 @@ import (
 
 // Execute is the primary entrypoint of the CLI app.
 func Execute() {
-	rootCmd := &cobra.Command{
+	os.Exit(execute(rootCmd()))
+}
+
+// execute runs the command and returns the exit code. Errors are printed to
+// printerr.
+func execute(cmd *cobra.Command) int {
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(printerr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func rootCmd() *cobra.Command {
+	cmd := &cobra.Command{
 		Use:           "chef",
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -20,13 +35,22 @@ func Execute() {
 			"Bootstrap a new project using predefined categories or bring your own layout.\n" +
 			"Add new components to an existing project.\n",
 		Version: "v0.1.0", // TODO (feat): add build info and version
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return setOutput()
+		},
 	}
 
-	rootCmd.AddCommand(initCmd())
-	rootCmd.AddCommand(componentsCmd())
+	projectLoc.RegisterPersistentString(cmd, &globals.Project, "")
+	verbose.RegisterPersistentBool(cmd, &globals.Verbose, false)
+	outputFormat.RegisterPersistentString(cmd, &globals.Output, string(display.Text))
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Printf("%v\n", err)
-		os.Exit(1)
-	}
+	cmd.AddCommand(initCmd())
+	cmd.AddCommand(componentsCmd())
+	cmd.AddCommand(notationCmd())
+	cmd.AddCommand(treeCmd())
+	cmd.AddCommand(doctorCmd())
+	cmd.AddCommand(syncCmd())
+	cmd.AddCommand(upgradeCmd())
+
+	return cmd
 }
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCmd(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+	}{
+		{desc: "prints version", args: []string{"--version"}},
+		{desc: "prints version with shorthand", args: []string{"-v"}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := rootCmd()
+			cmd.SetOut(&buf)
+			cmd.SetArgs(tC.args)
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+			assert.Equal(t, "chef version v0.1.0\n", buf.String())
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("keeps structured output valid when command fails", func(t *testing.T) {
+		t.Cleanup(func() { output = display.Text })
+		var stdout, stderr bytes.Buffer
+		printout, printerr = &stdout, &stderr
+
+		root := t.TempDir()
+		cmd := rootCmd()
+		cmd.SetArgs([]string{"init", "--name", "cheftest", "--category", "srv", "--server", "http",
+			"--module", "cheftest", "--root", root, "-o", "json"})
+		require.Equal(t, 0, execute(cmd))
+		require.NoError(t, os.Remove(path.Join(root, "cheftest", "main.go")))
+
+		stdout.Reset()
+		cmd = rootCmd()
+		cmd.SetArgs([]string{"doctor", "--project", path.Join(root, "cheftest"), "-o", "json"})
+		assert.Equal(t, 1, execute(cmd))
+
+		var doc display.DoctorDocument
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc))
+		assert.Len(t, doc.Problems, 1)
+		assert.Equal(t, "project doctor found 1 problem(s)\n", stderr.String())
+	})
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"github.com/antklim/chef/internal/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var overwrite = Flag{
+	LongForm:   "overwrite",
+	Help:       "Overwrite files which content differs from a fresh render.",
+	IsRequired: false,
+}
+
+func syncCmd() *cobra.Command {
+	var inputs struct {
+		Overwrite bool
+		DryRun    bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Args:  cobra.NoArgs,
+		Short: "Create missing project layout nodes",
+		Long: "Create the directories and files of the layout reconstructed from the notation,\n" +
+			"including the employed components, which are missing in the project. Existing\n" +
+			"files are left untouched unless --overwrite is given.",
+		Example: `chef sync
+chef sync --dry-run
+chef sync --overwrite --project ./myproject`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			if inputs.DryRun {
+				return syncPlanCmdRunner(p, inputs.Overwrite)
+			}
+			return syncCmdRunner(p, inputs.Overwrite)
+		},
+	}
+
+	overwrite.RegisterBool(cmd, &inputs.Overwrite, false)
+	dryRun.RegisterBool(cmd, &inputs.DryRun, false)
+
+	return cmd
+}
+
+func syncCmdRunner(p Project, overwrite bool) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	report, err := p.Sync(overwrite)
+	if err != nil {
+		return errors.Wrap(err, "project sync failed")
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewSyncDocument(p.Loc(), report))
+	}
+	return display.Sync(printout, p.Loc(), report)
+}
+
+func syncPlanCmdRunner(p Project, overwrite bool) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	entries, err := p.PlanSync(overwrite)
+	if err != nil {
+		return errors.Wrap(err, "project sync failed")
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewPlanDocument(entries))
+	}
+	return display.Plan(printout, entries)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := syncCmdRunner(p, false)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project sync failed", func(t *testing.T) {
+		p := FailedSync(errors.New("some sync error"))
+		err := syncCmdRunner(p, false)
+		assert.EqualError(t, err, "project sync failed: some sync error")
+	})
+
+	t.Run("shows changes made by sync", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{
+			loc:    "/tmp/cheftest",
+			report: project.SyncReport{Created: []string{"handler"}, Overwritten: []string{"main.go"}},
+		}
+		err := syncCmdRunner(p, true)
+		assert.NoError(t, err)
+
+		bufs := buf.String()
+		assert.Contains(t, bufs, "project synced at /tmp/cheftest:\n")
+		assert.Contains(t, bufs, "created\t\thandler\n")
+		assert.Contains(t, bufs, "overwritten\tmain.go\n")
+	})
+}
+
+func TestSyncPlanCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := syncPlanCmdRunner(p, false)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project plan failed", func(t *testing.T) {
+		p := FailedPlan(errors.New("some plan error"))
+		err := syncPlanCmdRunner(p, false)
+		assert.EqualError(t, err, "project sync failed: some plan error")
+	})
+
+	t.Run("shows planned entries", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{entries: []fsys.Entry{{Path: "/tmp/project/main.go", Perm: 0644, Size: 10}}}
+		err := syncPlanCmdRunner(p, false)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "dry run, planned changes:\n")
+	})
+}
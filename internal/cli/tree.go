@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"github.com/antklim/chef/internal/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func treeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Args:  cobra.NoArgs,
+		Short: "Show project layout",
+		Long: "Show project layout as a tree. Each node is annotated with its type, permissions,\n" +
+			"template, owning component and whether it exists, drifted or is missing on disk.",
+		Example: `chef tree
+chef tree --project ./myproject`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			return treeCmdRunner(p)
+		},
+	}
+
+	return cmd
+}
+
+func treeCmdRunner(p Project) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	nodes, err := p.Inspect()
+	if err != nil {
+		return errors.Wrap(err, "inspect project failed")
+	}
+
+	if output.Structured() {
+		return display.Document(printout, output, display.NewTreeDocument(p.Loc(), nodes))
+	}
+	return display.Tree(printout, p.Loc(), nodes)
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := treeCmdRunner(p)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project inspect failed", func(t *testing.T) {
+		p := FailedInspect(errors.New("some inspect error"))
+		err := treeCmdRunner(p)
+		assert.EqualError(t, err, "inspect project failed: some inspect error")
+	})
+
+	t.Run("displays project tree", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{
+			loc:   "/tmp/cheftest",
+			nodes: []project.NodeInfo{{Path: "main.go", Perm: 0644, Template: "main", State: project.NodeMissing}},
+		}
+		err := treeCmdRunner(p)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "└── main.go  file  0644  main")
+	})
+}
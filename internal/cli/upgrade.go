@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/display"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func upgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Args:  cobra.NoArgs,
+		Short: "Upgrade project files to the current templates",
+		Long: "Render the project files with the current templates and merge the template changes\n" +
+			"into the files in the project. Changes are found against the baseline the files\n" +
+			"were generated with, stored in " + chef.DefaultBaselineFileName + ". Lines changed both in\n" +
+			"the project and by the templates are written between conflict markers. Exits with\n" +
+			"non-zero status when conflicts found.",
+		Example: `chef upgrade
+chef upgrade --project ./myproject`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := initProject()
+			if err != nil {
+				return err
+			}
+			return upgradeCmdRunner(p)
+		},
+	}
+
+	return cmd
+}
+
+func upgradeCmdRunner(p Project) error {
+	if err := p.Init(); err != nil {
+		return errors.Wrap(err, "init project failed")
+	}
+
+	report, err := p.Upgrade()
+	if err != nil {
+		return errors.Wrap(err, "project upgrade failed")
+	}
+
+	if output.Structured() {
+		err = display.Document(printout, output, display.NewUpgradeDocument(p.Loc(), report))
+	} else {
+		err = display.Upgrade(printout, p.Loc(), report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(report.Conflicted) > 0 {
+		return errors.Errorf("project upgrade found %d conflict(s)", len(report.Conflicted))
+	}
+	return nil
+}
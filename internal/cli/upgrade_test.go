@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeCmdRunner(t *testing.T) {
+	t.Run("fails when project init failed", func(t *testing.T) {
+		p := FailedInit(errors.New("some init error"))
+		err := upgradeCmdRunner(p)
+		assert.EqualError(t, err, "init project failed: some init error")
+	})
+
+	t.Run("fails when project upgrade failed", func(t *testing.T) {
+		p := FailedUpgrade(errors.New("some upgrade error"))
+		err := upgradeCmdRunner(p)
+		assert.EqualError(t, err, "project upgrade failed: some upgrade error")
+	})
+
+	t.Run("fails when conflicts found", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{
+			loc:     "/tmp/cheftest",
+			upgrade: project.UpgradeReport{Merged: []string{"go.mod"}, Conflicted: []string{"main.go"}},
+		}
+		err := upgradeCmdRunner(p)
+		assert.EqualError(t, err, "project upgrade found 1 conflict(s)")
+
+		bufs := buf.String()
+		assert.Contains(t, bufs, "merged\t\tgo.mod\n")
+		assert.Contains(t, bufs, "conflicted\tmain.go\n")
+	})
+
+	t.Run("succeeds when files merged cleanly", func(t *testing.T) {
+		var buf bytes.Buffer
+		printout = &buf
+
+		p := projMock{loc: "/tmp/cheftest", upgrade: project.UpgradeReport{Merged: []string{"main.go"}}}
+		err := upgradeCmdRunner(p)
+		assert.NoError(t, err)
+		assert.Equal(t, "project upgraded at /tmp/cheftest:\nRESULT\tPATH\nmerged\tmain.go\n", buf.String())
+	})
+}
@@ -28,6 +28,24 @@ func ComponentsEmploy(w io.Writer, name, component string) error {
 	return ew.err
 }
 
+func ComponentsUnemploy(w io.Writer, name, component string) error {
+	ew := &errorWriter{Writer: w}
+	fmt.Fprintf(ew, "successfully removed %q %q component\n", name, component)
+	return ew.err
+}
+
+func ComponentsRename(w io.Writer, name, to, component string) error {
+	ew := &errorWriter{Writer: w}
+	fmt.Fprintf(ew, "successfully renamed %q %q component to %q\n", name, component, to)
+	return ew.err
+}
+
+func ComponentsRegister(w io.Writer, name string) error {
+	ew := &errorWriter{Writer: w}
+	fmt.Fprintf(ew, "successfully registered %q component\n", name)
+	return ew.err
+}
+
 func componentsList(w io.Writer, components []project.Component) error {
 	fmt.Fprintln(w, componentsListTitle)
 
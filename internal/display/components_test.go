@@ -39,3 +39,24 @@ func TestComponentsEmploy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, `successfully added "health.go" as "http_handler" component`+"\n", buf.String())
 }
+
+func TestComponentsUnemploy(t *testing.T) {
+	var buf bytes.Buffer
+	err := display.ComponentsUnemploy(&buf, "health", "http_handler")
+	assert.NoError(t, err)
+	assert.Equal(t, `successfully removed "health" "http_handler" component`+"\n", buf.String())
+}
+
+func TestComponentsRename(t *testing.T) {
+	var buf bytes.Buffer
+	err := display.ComponentsRename(&buf, "health", "status", "http_handler")
+	assert.NoError(t, err)
+	assert.Equal(t, `successfully renamed "health" "http_handler" component to "status"`+"\n", buf.String())
+}
+
+func TestComponentsRegister(t *testing.T) {
+	var buf bytes.Buffer
+	err := display.ComponentsRegister(&buf, "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, `successfully registered "repo" component`+"\n", buf.String())
+}
@@ -0,0 +1,43 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/antklim/chef/internal/project"
+)
+
+const (
+	doctorTitle    = "project doctor at %s:\n"
+	doctorFormat   = "%s\t%s\n"
+	doctorEmptyMsg = "\tno problems found"
+)
+
+// Doctor outputs problems found in the project comparing it with its layout.
+func Doctor(w io.Writer, loc string, findings []project.Finding) error {
+	ew := &errorWriter{Writer: w}
+	err := doctor(ew, loc, findings)
+	if ew.err != nil {
+		return ew.err
+	}
+	return err
+}
+
+func doctor(w io.Writer, loc string, findings []project.Finding) error {
+	fmt.Fprintf(w, doctorTitle, loc)
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, doctorEmptyMsg)
+		return nil
+	}
+
+	tw.Init(w, minwidth, tabwidth, padding, padchar, flags)
+
+	fmt.Fprintf(tw, doctorFormat, "PATH", "PROBLEM")
+	for _, f := range findings {
+		fmt.Fprintf(tw, doctorFormat, f.Path, f.Problem)
+	}
+
+	err := tw.Flush()
+	return err
+}
@@ -0,0 +1,36 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Run("displays found problems", func(t *testing.T) {
+		findings := []project.Finding{
+			{Path: "test", Problem: "missing directory"},
+			{Path: "main.go", Problem: "content differs"},
+		}
+
+		var buf bytes.Buffer
+		err := display.Doctor(&buf, "/tmp/cheftest", findings)
+		assert.NoError(t, err)
+
+		expected := "project doctor at /tmp/cheftest:\n" +
+			"PATH\tPROBLEM\n" +
+			"test\tmissing directory\n" +
+			"main.go\tcontent differs\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("displays an information message when no problems found", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Doctor(&buf, "/tmp/cheftest", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "project doctor at /tmp/cheftest:\n\tno problems found\n", buf.String())
+	})
+}
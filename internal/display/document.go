@@ -0,0 +1,257 @@
+package display
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/project"
+)
+
+// The following are the documents of the structured output. Field names are
+// part of the stable output schema. Slices are never null in the output.
+
+// ComponentDocument describes a registered component.
+type ComponentDocument struct {
+	Name string `json:"name" yaml:"name"`
+	Loc  string `json:"loc" yaml:"loc"`
+	Desc string `json:"desc" yaml:"desc"`
+}
+
+// ComponentsDocument is the output of the components list command.
+type ComponentsDocument struct {
+	Components []ComponentDocument `json:"components" yaml:"components"`
+}
+
+// InitDocument is the output of the init command.
+type InitDocument struct {
+	Location   string              `json:"location" yaml:"location"`
+	Created    []string            `json:"created" yaml:"created"` // relative to location
+	Components []ComponentDocument `json:"components" yaml:"components"`
+}
+
+// EmployDocument is the output of the components employ command.
+type EmployDocument struct {
+	Location  string   `json:"location" yaml:"location"`
+	Component string   `json:"component" yaml:"component"`
+	Name      string   `json:"name" yaml:"name"`
+	Created   []string `json:"created" yaml:"created"` // relative to location
+}
+
+// UnemployDocument is the output of the components unemploy command.
+type UnemployDocument struct {
+	Location  string   `json:"location" yaml:"location"`
+	Component string   `json:"component" yaml:"component"`
+	Name      string   `json:"name" yaml:"name"`
+	Removed   []string `json:"removed" yaml:"removed"` // relative to location
+}
+
+// RenameDocument is the output of the components rename command.
+type RenameDocument struct {
+	Location  string   `json:"location" yaml:"location"`
+	Component string   `json:"component" yaml:"component"`
+	From      string   `json:"from" yaml:"from"`
+	To        string   `json:"to" yaml:"to"`
+	Renamed   []string `json:"renamed" yaml:"renamed"` // new locations relative to location
+}
+
+// RegisterDocument is the output of the components register command.
+type RegisterDocument struct {
+	Component string `json:"component" yaml:"component"`
+}
+
+// PlanDocument is the output of the commands run with dry-run flag.
+type PlanDocument struct {
+	Planned []EntryDocument `json:"planned" yaml:"planned"`
+}
+
+// EntryDocument describes a planned file system entry.
+type EntryDocument struct {
+	Path string `json:"path" yaml:"path"`
+	Dir  bool   `json:"dir" yaml:"dir"`
+	Perm string `json:"perm" yaml:"perm"`
+	Size int64  `json:"size" yaml:"size"`
+}
+
+// TreeDocument is the output of the tree command.
+type TreeDocument struct {
+	Location string         `json:"location" yaml:"location"`
+	Nodes    []NodeDocument `json:"nodes" yaml:"nodes"`
+}
+
+// NodeDocument describes a project layout node.
+type NodeDocument struct {
+	Path      string         `json:"path" yaml:"path"` // relative to location
+	Type      string         `json:"type" yaml:"type"` // dir or file
+	Perm      string         `json:"perm" yaml:"perm"`
+	Template  string         `json:"template" yaml:"template"`
+	Component string         `json:"component" yaml:"component"`
+	Status    string         `json:"status" yaml:"status"` // exists, drifted or missing
+	Drift     []string       `json:"drift" yaml:"drift"`
+	Nodes     []NodeDocument `json:"nodes" yaml:"nodes"`
+}
+
+// DoctorDocument is the output of the doctor command.
+type DoctorDocument struct {
+	Location string            `json:"location" yaml:"location"`
+	Problems []ProblemDocument `json:"problems" yaml:"problems"`
+}
+
+// ProblemDocument describes a problem found by the doctor command.
+type ProblemDocument struct {
+	Path    string `json:"path" yaml:"path"` // relative to location
+	Problem string `json:"problem" yaml:"problem"`
+}
+
+// SyncDocument is the output of the sync command.
+type SyncDocument struct {
+	Location    string   `json:"location" yaml:"location"`
+	Created     []string `json:"created" yaml:"created"`         // relative to location
+	Overwritten []string `json:"overwritten" yaml:"overwritten"` // relative to location
+}
+
+// UpgradeDocument is the output of the upgrade command.
+type UpgradeDocument struct {
+	Location   string   `json:"location" yaml:"location"`
+	Merged     []string `json:"merged" yaml:"merged"`         // relative to location
+	Conflicted []string `json:"conflicted" yaml:"conflicted"` // relative to location
+}
+
+// NotationMigrateDocument is the output of the notation migrate command.
+type NotationMigrateDocument struct {
+	From   int    `json:"from" yaml:"from"`
+	To     int    `json:"to" yaml:"to"`
+	Backup string `json:"backup" yaml:"backup"` // empty when notation is up to date
+}
+
+// NewComponentsDocument creates a document of the registered components.
+func NewComponentsDocument(components []project.Component) ComponentsDocument {
+	return ComponentsDocument{Components: componentDocuments(components)}
+}
+
+// NewInitDocument creates a document of the inited project.
+func NewInitDocument(loc string, created []string, components []project.Component) InitDocument {
+	return InitDocument{
+		Location:   loc,
+		Created:    nonNil(created),
+		Components: componentDocuments(components),
+	}
+}
+
+// NewEmployDocument creates a document of the employed component.
+func NewEmployDocument(loc, name, component string, created []string) EmployDocument {
+	return EmployDocument{
+		Location:  loc,
+		Component: component,
+		Name:      name,
+		Created:   nonNil(created),
+	}
+}
+
+// NewUnemployDocument creates a document of the unemployed component.
+func NewUnemployDocument(loc, name, component string, removed []string) UnemployDocument {
+	return UnemployDocument{
+		Location:  loc,
+		Component: component,
+		Name:      name,
+		Removed:   nonNil(removed),
+	}
+}
+
+// NewRenameDocument creates a document of the renamed component.
+func NewRenameDocument(loc, component, from, to string, renamed []string) RenameDocument {
+	return RenameDocument{
+		Location:  loc,
+		Component: component,
+		From:      from,
+		To:        to,
+		Renamed:   nonNil(renamed),
+	}
+}
+
+// NewPlanDocument creates a document of the planned entries.
+func NewPlanDocument(entries []fsys.Entry) PlanDocument {
+	planned := make([]EntryDocument, 0, len(entries))
+	for _, e := range entries {
+		planned = append(planned, EntryDocument{
+			Path: e.Path,
+			Dir:  e.Dir,
+			Perm: formatPerm(e.Perm),
+			Size: e.Size,
+		})
+	}
+	return PlanDocument{Planned: planned}
+}
+
+// NewTreeDocument creates a document of the project layout nodes.
+func NewTreeDocument(loc string, nodes []project.NodeInfo) TreeDocument {
+	return TreeDocument{Location: loc, Nodes: nodeDocuments(nodes)}
+}
+
+// NewDoctorDocument creates a document of the problems found in the project.
+func NewDoctorDocument(loc string, findings []project.Finding) DoctorDocument {
+	problems := make([]ProblemDocument, 0, len(findings))
+	for _, f := range findings {
+		problems = append(problems, ProblemDocument{Path: f.Path, Problem: f.Problem})
+	}
+	return DoctorDocument{Location: loc, Problems: problems}
+}
+
+// NewSyncDocument creates a document of the changes made by the project sync.
+func NewSyncDocument(loc string, report project.SyncReport) SyncDocument {
+	return SyncDocument{
+		Location:    loc,
+		Created:     nonNil(report.Created),
+		Overwritten: nonNil(report.Overwritten),
+	}
+}
+
+// NewUpgradeDocument creates a document of the files changed by the project
+// upgrade.
+func NewUpgradeDocument(loc string, report project.UpgradeReport) UpgradeDocument {
+	return UpgradeDocument{
+		Location:   loc,
+		Merged:     nonNil(report.Merged),
+		Conflicted: nonNil(report.Conflicted),
+	}
+}
+
+func componentDocuments(components []project.Component) []ComponentDocument {
+	docs := make([]ComponentDocument, 0, len(components))
+	for _, c := range components {
+		docs = append(docs, ComponentDocument{Name: c.Name, Loc: c.Loc, Desc: c.Desc})
+	}
+	return docs
+}
+
+func nodeDocuments(nodes []project.NodeInfo) []NodeDocument {
+	docs := make([]NodeDocument, 0, len(nodes))
+	for _, n := range nodes {
+		typ := "file"
+		if n.Dir {
+			typ = "dir"
+		}
+		docs = append(docs, NodeDocument{
+			Path:      n.Path,
+			Type:      typ,
+			Perm:      formatPerm(n.Perm),
+			Template:  n.Template,
+			Component: n.Component,
+			Status:    n.State.String(),
+			Drift:     nonNil(n.Drift),
+			Nodes:     nodeDocuments(n.Nodes),
+		})
+	}
+	return docs
+}
+
+func formatPerm(perm fs.FileMode) string {
+	return fmt.Sprintf("%04o", uint32(perm))
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -3,6 +3,7 @@ package display
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/antklim/chef/internal/project"
 )
@@ -10,11 +11,16 @@ import (
 // TODO (ref): encapsulate location to project and pass project instance to this
 // ProjectInit
 
-// ProjectInit outputs information about inited project.
-func ProjectInit(w io.Writer, loc string, components []project.Component) error {
+// ProjectInit outputs information about inited project. Modules required by
+// the project are listed with a hint to fetch them.
+func ProjectInit(w io.Writer, loc string, components []project.Component, deps []string) error {
 	ew := &errorWriter{Writer: w}
 
 	fmt.Fprintf(ew, "project successfully inited at %s\n\n", loc)
+	if len(deps) > 0 {
+		fmt.Fprintf(ew, "go.mod requires %s, run `go mod tidy` in the project to fetch them\n\n",
+			strings.Join(deps, ", "))
+	}
 
 	err := componentsList(ew, components)
 	if ew.err != nil {
@@ -22,3 +28,17 @@ func ProjectInit(w io.Writer, loc string, components []project.Component) error
 	}
 	return err
 }
+
+// ProjectVerify outputs information about verified project.
+func ProjectVerify(w io.Writer) error {
+	ew := &errorWriter{Writer: w}
+	fmt.Fprintln(ew, "project successfully verified")
+	return ew.err
+}
+
+// ProjectRoot outputs the location of the project root.
+func ProjectRoot(w io.Writer, loc string) error {
+	ew := &errorWriter{Writer: w}
+	fmt.Fprintf(ew, "project root: %s\n", loc)
+	return ew.err
+}
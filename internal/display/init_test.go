@@ -14,12 +14,48 @@ func TestProjectInit(t *testing.T) {
 		{Name: "header", Loc: "internal/header", Desc: "header component"},
 	}
 
+	testCases := []struct {
+		desc     string
+		deps     []string
+		expected string
+	}{
+		{
+			desc: "outputs inited project",
+			expected: "project successfully inited at /tmp/cheftest\n\n" +
+				"registered components:\n" +
+				"NAME\tLOCATION\tDESCRIPTION\nheader\tinternal/header\theader component\n",
+		},
+		{
+			desc: "outputs required modules",
+			deps: []string{"google.golang.org/grpc", "google.golang.org/protobuf"},
+			expected: "project successfully inited at /tmp/cheftest\n\n" +
+				"go.mod requires google.golang.org/grpc, google.golang.org/protobuf, " +
+				"run `go mod tidy` in the project to fetch them\n\n" +
+				"registered components:\n" +
+				"NAME\tLOCATION\tDESCRIPTION\nheader\tinternal/header\theader component\n",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := display.ProjectInit(&buf, "/tmp/cheftest", components, tC.deps)
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, buf.String())
+		})
+	}
+}
+
+func TestProjectVerify(t *testing.T) {
 	var buf bytes.Buffer
-	err := display.ProjectInit(&buf, "/tmp/cheftest", components)
+	err := display.ProjectVerify(&buf)
 	assert.NoError(t, err)
+	assert.Equal(t, "project successfully verified\n", buf.String())
+}
 
-	expected := "project successfully inited at /tmp/cheftest\n\n" +
-		"registered components:\n" +
-		"NAME\tLOCATION\tDESCRIPTION\nheader\tinternal/header\theader component\n"
-	assert.Equal(t, expected, buf.String())
+func TestProjectRoot(t *testing.T) {
+	var buf bytes.Buffer
+	err := display.ProjectRoot(&buf, "/tmp/cheftest")
+	assert.NoError(t, err)
+	assert.Equal(t, "project root: /tmp/cheftest\n", buf.String())
 }
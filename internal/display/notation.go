@@ -0,0 +1,18 @@
+package display
+
+import (
+	"fmt"
+	"io"
+)
+
+// NotationMigrate outputs information about migrated notation.
+func NotationMigrate(w io.Writer, from, to int, backup string) error {
+	ew := &errorWriter{Writer: w}
+	if from == to {
+		fmt.Fprintf(ew, "notation is up to date, schema version %d\n", to)
+		return ew.err
+	}
+	fmt.Fprintf(ew, "notation migrated from schema version %d to %d\n", from, to)
+	fmt.Fprintf(ew, "backup saved to %s\n", backup)
+	return ew.err
+}
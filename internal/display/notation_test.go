@@ -0,0 +1,36 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotationMigrate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		from     int
+		expected string
+	}{
+		{
+			desc:     "shows migrated notation",
+			from:     1,
+			expected: "notation migrated from schema version 1 to 2\nbackup saved to /tmp/.chef.yml.bak\n",
+		},
+		{
+			desc:     "shows up to date notation",
+			from:     2,
+			expected: "notation is up to date, schema version 2\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := display.NotationMigrate(&buf, tC.from, 2, "/tmp/.chef.yml.bak")
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format.
+type Format string
+
+// Supported output formats.
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// ParseFormat returns the output format by its name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case Text, JSON, YAML:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, expected text, json or yaml", s)
+}
+
+// Structured returns true when the format is a machine-readable one.
+func (f Format) Structured() bool {
+	return f == JSON || f == YAML
+}
+
+// Document outputs the document in the structured format.
+func Document(w io.Writer, f Format, doc interface{}) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return fmt.Errorf("format %q is not a document format", f)
+}
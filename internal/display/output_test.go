@@ -0,0 +1,85 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		name     string
+		expected display.Format
+		err      string
+	}{
+		{desc: "text", name: "text", expected: display.Text},
+		{desc: "json", name: "json", expected: display.JSON},
+		{desc: "yaml", name: "yaml", expected: display.YAML},
+		{desc: "unknown", name: "xml", err: `unknown output format "xml", expected text, json or yaml`},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			f, err := display.ParseFormat(tC.name)
+			if tC.err != "" {
+				assert.EqualError(t, err, tC.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, f)
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	doc := display.NewInitDocument("/tmp/cheftest", []string{"handler", "main.go"}, []project.Component{
+		{Name: "http_handler", Loc: "handler", Desc: "HTTP handler"},
+	})
+
+	t.Run("outputs json document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Document(&buf, display.JSON, doc)
+		require.NoError(t, err)
+
+		expected := `{
+  "location": "/tmp/cheftest",
+  "created": ["handler", "main.go"],
+  "components": [{"name": "http_handler", "loc": "handler", "desc": "HTTP handler"}]
+}`
+		assert.JSONEq(t, expected, buf.String())
+	})
+
+	t.Run("outputs yaml document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Document(&buf, display.YAML, doc)
+		require.NoError(t, err)
+
+		expected := `location: /tmp/cheftest
+created:
+  - handler
+  - main.go
+components:
+  - name: http_handler
+    loc: handler
+    desc: HTTP handler
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("outputs empty lists", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Document(&buf, display.JSON, display.NewComponentsDocument(nil))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"components": []}`, buf.String())
+	})
+
+	t.Run("fails to output text document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Document(&buf, display.Text, doc)
+		assert.EqualError(t, err, `format "text" is not a document format`)
+	})
+}
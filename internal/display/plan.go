@@ -0,0 +1,67 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/antklim/chef/internal/fsys"
+)
+
+const (
+	planTitle        = "dry run, planned changes:"
+	planFormat       = "%s\t%s\t%s\n"
+	planEmptyListMsg = "\tno changes planned"
+	planIndent       = "  "
+)
+
+// Plan outputs a tree of entries planned to be created.
+func Plan(w io.Writer, entries []fsys.Entry) error {
+	ew := &errorWriter{Writer: w}
+	err := plan(ew, entries)
+	if ew.err != nil {
+		return ew.err
+	}
+	return err
+}
+
+func plan(w io.Writer, entries []fsys.Entry) error {
+	fmt.Fprintln(w, planTitle)
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, planEmptyListMsg)
+		return nil
+	}
+
+	tw.Init(w, minwidth, tabwidth, padding, padchar, flags)
+
+	fmt.Fprintf(tw, planFormat, "MODE", "SIZE", "PATH")
+
+	root := path.Dir(entries[0].Path)
+	for _, e := range entries {
+		size := "-"
+		if !e.Dir {
+			size = strconv.FormatInt(e.Size, 10)
+		}
+		fmt.Fprintf(tw, planFormat, e.Mode(), size, planPath(root, e))
+	}
+
+	err := tw.Flush()
+	return err
+}
+
+// planPath returns entry path indented according to the entry depth relative
+// to the root. Entries at the root level displayed with the full path.
+func planPath(root string, e fsys.Entry) string {
+	p := e.Path
+	rel := strings.TrimPrefix(e.Path, root+"/")
+	if depth := strings.Count(rel, "/"); depth > 0 {
+		p = strings.Repeat(planIndent, depth) + path.Base(e.Path)
+	}
+	if e.Dir {
+		p += "/"
+	}
+	return p
+}
@@ -0,0 +1,40 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	t.Run("displays a tree of planned entries", func(t *testing.T) {
+		entries := []fsys.Entry{
+			{Path: "/tmp/cheftest", Perm: 0755, Dir: true},
+			{Path: "/tmp/cheftest/handler", Perm: 0700, Dir: true},
+			{Path: "/tmp/cheftest/handler/router.go", Perm: 0644, Size: 92},
+			{Path: "/tmp/cheftest/main.go", Perm: 0600, Size: 13},
+		}
+
+		var buf bytes.Buffer
+		err := display.Plan(&buf, entries)
+		assert.NoError(t, err)
+
+		expected := "dry run, planned changes:\n" +
+			"MODE\t\tSIZE\tPATH\n" +
+			"drwxr-xr-x\t-\t/tmp/cheftest/\n" +
+			"drwx------\t-\t  handler/\n" +
+			"-rw-r--r--\t92\t    router.go\n" +
+			"-rw-------\t13\t  main.go\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("displays an information message when nothing planned", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Plan(&buf, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "dry run, planned changes:\n\tno changes planned\n", buf.String())
+	})
+}
@@ -0,0 +1,47 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/antklim/chef/internal/project"
+)
+
+const (
+	syncTitle    = "project synced at %s:\n"
+	syncFormat   = "%s\t%s\n"
+	syncEmptyMsg = "\tno changes, project is up to date"
+)
+
+// Sync outputs directories and files created and overwritten by the project
+// sync.
+func Sync(w io.Writer, loc string, report project.SyncReport) error {
+	ew := &errorWriter{Writer: w}
+	err := sync(ew, loc, report)
+	if ew.err != nil {
+		return ew.err
+	}
+	return err
+}
+
+func sync(w io.Writer, loc string, report project.SyncReport) error {
+	fmt.Fprintf(w, syncTitle, loc)
+
+	if len(report.Created) == 0 && len(report.Overwritten) == 0 {
+		fmt.Fprintln(w, syncEmptyMsg)
+		return nil
+	}
+
+	tw.Init(w, minwidth, tabwidth, padding, padchar, flags)
+
+	fmt.Fprintf(tw, syncFormat, "CHANGE", "PATH")
+	for _, loc := range report.Created {
+		fmt.Fprintf(tw, syncFormat, "created", loc)
+	}
+	for _, loc := range report.Overwritten {
+		fmt.Fprintf(tw, syncFormat, "overwritten", loc)
+	}
+
+	err := tw.Flush()
+	return err
+}
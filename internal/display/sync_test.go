@@ -0,0 +1,37 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync(t *testing.T) {
+	t.Run("displays created and overwritten entries", func(t *testing.T) {
+		report := project.SyncReport{
+			Created:     []string{"test", "go.mod"},
+			Overwritten: []string{"main.go"},
+		}
+
+		var buf bytes.Buffer
+		err := display.Sync(&buf, "/tmp/cheftest", report)
+		assert.NoError(t, err)
+
+		expected := "project synced at /tmp/cheftest:\n" +
+			"CHANGE\t\tPATH\n" +
+			"created\t\ttest\n" +
+			"created\t\tgo.mod\n" +
+			"overwritten\tmain.go\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("displays an information message when nothing changed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Sync(&buf, "/tmp/cheftest", project.SyncReport{})
+		assert.NoError(t, err)
+		assert.Equal(t, "project synced at /tmp/cheftest:\n\tno changes, project is up to date\n", buf.String())
+	})
+}
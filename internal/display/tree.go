@@ -0,0 +1,91 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/antklim/chef/internal/project"
+)
+
+// the tree is aligned with spaces, node names of tab width multiple would run
+// into the next column with tab padding
+const (
+	treePadding      = 2
+	treePadchar byte = ' '
+)
+
+const (
+	treeFormat   = "%s\t%s\t%s\t%s\t%s\t%s\n"
+	treeEmptyMsg = "\tproject layout is empty"
+	treeBranch   = "├── "
+	treeLast     = "└── "
+	treePipe     = "│   "
+	treeSpace    = "    "
+)
+
+// Tree outputs project layout nodes as a tree.
+func Tree(w io.Writer, loc string, nodes []project.NodeInfo) error {
+	ew := &errorWriter{Writer: w}
+	err := tree(ew, loc, nodes)
+	if ew.err != nil {
+		return ew.err
+	}
+	return err
+}
+
+func tree(w io.Writer, loc string, nodes []project.NodeInfo) error {
+	fmt.Fprintln(w, loc)
+
+	if len(nodes) == 0 {
+		fmt.Fprintln(w, treeEmptyMsg)
+		return nil
+	}
+
+	ttw := tabwriter.NewWriter(w, minwidth, tabwidth, treePadding, treePadchar, flags)
+
+	fmt.Fprintf(ttw, treeFormat, "NODE", "TYPE", "PERM", "TEMPLATE", "COMPONENT", "STATUS")
+	treeNodes(ttw, nodes, "")
+
+	err := ttw.Flush()
+	return err
+}
+
+func treeNodes(w io.Writer, nodes []project.NodeInfo, prefix string) {
+	for i, n := range nodes {
+		branch, indent := treeBranch, treePipe
+		if i == len(nodes)-1 {
+			branch, indent = treeLast, treeSpace
+		}
+
+		name, typ := n.Name(), "file"
+		if n.Dir {
+			name, typ = name+"/", "dir"
+		}
+
+		fmt.Fprintf(w, treeFormat,
+			prefix+branch+name,
+			typ,
+			formatPerm(n.Perm),
+			orDash(n.Template),
+			orDash(n.Component),
+			treeStatus(n))
+
+		treeNodes(w, n.Nodes, prefix+indent)
+	}
+}
+
+func treeStatus(n project.NodeInfo) string {
+	if len(n.Drift) == 0 {
+		return n.State.String()
+	}
+	return fmt.Sprintf("%s (%s)", n.State, strings.Join(n.Drift, ", "))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
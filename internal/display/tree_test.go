@@ -0,0 +1,58 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	t.Run("displays a tree of layout nodes", func(t *testing.T) {
+		nodes := []project.NodeInfo{
+			{Path: "handler", Dir: true, Perm: 0755, State: project.NodeExists, Nodes: []project.NodeInfo{
+				{Path: "handler/echo.go", Perm: 0644, Template: "http_endpoint", Component: "http_handler", State: project.NodeExists},
+				{Path: "handler/router.go", Perm: 0644, Template: "http_router", State: project.NodeDrifted, Drift: []string{"content differs"}},
+			}},
+			{Path: "test", Dir: true, Perm: 0755, State: project.NodeMissing},
+		}
+
+		var buf bytes.Buffer
+		err := display.Tree(&buf, "/tmp/cheftest", nodes)
+		assert.NoError(t, err)
+
+		expected := "/tmp/cheftest\n" +
+			"NODE               TYPE  PERM  TEMPLATE       COMPONENT     STATUS\n" +
+			"├── handler/       dir   0755  -              -             exists\n" +
+			"│   ├── echo.go    file  0644  http_endpoint  http_handler  exists\n" +
+			"│   └── router.go  file  0644  http_router    -             drifted (content differs)\n" +
+			"└── test/          dir   0755  -              -             missing\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("separates node names of tab width multiple", func(t *testing.T) {
+		nodes := []project.NodeInfo{
+			{Path: "handlers", Dir: true, Perm: 0755, State: project.NodeExists},
+			{Path: "user-profile_test.go", Perm: 0644, Template: "pkg_type", State: project.NodeExists},
+		}
+
+		var buf bytes.Buffer
+		err := display.Tree(&buf, "/tmp/cheftest", nodes)
+		assert.NoError(t, err)
+
+		expected := "/tmp/cheftest\n" +
+			"NODE                      TYPE  PERM  TEMPLATE  COMPONENT  STATUS\n" +
+			"├── handlers/             dir   0755  -         -          exists\n" +
+			"└── user-profile_test.go  file  0644  pkg_type  -          exists\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("displays an information message when layout is empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Tree(&buf, "/tmp/cheftest", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/cheftest\n\tproject layout is empty\n", buf.String())
+	})
+}
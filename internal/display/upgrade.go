@@ -0,0 +1,47 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/antklim/chef/internal/project"
+)
+
+const (
+	upgradeTitle    = "project upgraded at %s:\n"
+	upgradeFormat   = "%s\t%s\n"
+	upgradeEmptyMsg = "\tno changes, project is up to date"
+)
+
+// Upgrade outputs files cleanly merged and files with conflicts by the project
+// upgrade.
+func Upgrade(w io.Writer, loc string, report project.UpgradeReport) error {
+	ew := &errorWriter{Writer: w}
+	err := upgrade(ew, loc, report)
+	if ew.err != nil {
+		return ew.err
+	}
+	return err
+}
+
+func upgrade(w io.Writer, loc string, report project.UpgradeReport) error {
+	fmt.Fprintf(w, upgradeTitle, loc)
+
+	if len(report.Merged) == 0 && len(report.Conflicted) == 0 {
+		fmt.Fprintln(w, upgradeEmptyMsg)
+		return nil
+	}
+
+	tw.Init(w, minwidth, tabwidth, padding, padchar, flags)
+
+	fmt.Fprintf(tw, upgradeFormat, "RESULT", "PATH")
+	for _, loc := range report.Merged {
+		fmt.Fprintf(tw, upgradeFormat, "merged", loc)
+	}
+	for _, loc := range report.Conflicted {
+		fmt.Fprintf(tw, upgradeFormat, "conflicted", loc)
+	}
+
+	err := tw.Flush()
+	return err
+}
@@ -0,0 +1,37 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antklim/chef/internal/display"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgrade(t *testing.T) {
+	t.Run("displays merged and conflicted files", func(t *testing.T) {
+		report := project.UpgradeReport{
+			Merged:     []string{"main.go", "handler/http/router.go"},
+			Conflicted: []string{"server/http/server.go"},
+		}
+
+		var buf bytes.Buffer
+		err := display.Upgrade(&buf, "/tmp/cheftest", report)
+		assert.NoError(t, err)
+
+		expected := "project upgraded at /tmp/cheftest:\n" +
+			"RESULT\t\tPATH\n" +
+			"merged\t\tmain.go\n" +
+			"merged\t\thandler/http/router.go\n" +
+			"conflicted\tserver/http/server.go\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("displays an information message when nothing changed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := display.Upgrade(&buf, "/tmp/cheftest", project.UpgradeReport{})
+		assert.NoError(t, err)
+		assert.Equal(t, "project upgraded at /tmp/cheftest:\n\tno changes, project is up to date\n", buf.String())
+	})
+}
@@ -0,0 +1,2 @@
+// Package fsys provides writable file systems to build project layouts.
+package fsys
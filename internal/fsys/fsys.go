@@ -0,0 +1,286 @@
+package fsys
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing/fstest"
+)
+
+// FS is the interface implemented by a writable file system.
+type FS interface {
+	// Mkdir creates a new directory with the specified name and permissions.
+	Mkdir(name string, perm fs.FileMode) error
+	// WriteFile writes data to the named file, creating it if necessary. The
+	// file permissions set to perm.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// Rename renames (moves) oldpath to newpath.
+	Rename(oldpath, newpath string) error
+	// RemoveAll removes path and any children it contains. It returns nil if
+	// the path does not exist.
+	RemoveAll(path string) error
+	// Stat returns a FileInfo describing the named file.
+	Stat(name string) (fs.FileInfo, error)
+	// FileContent reads the named file and returns its contents.
+	FileContent(name string) ([]byte, error)
+	// DirEntries reads the named directory and returns its entries sorted by
+	// file name.
+	DirEntries(name string) ([]fs.DirEntry, error)
+}
+
+// OSFS is a writable file system backed by the os package.
+type OSFS struct{}
+
+// Mkdir creates a new directory with the specified name and permissions (before
+// umask).
+func (OSFS) Mkdir(name string, perm fs.FileMode) error {
+	return os.Mkdir(name, perm)
+}
+
+// WriteFile writes data to the named file, creating it if necessary. The file
+// permissions set to perm regardless of umask.
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Rename renames (moves) oldpath to newpath.
+func (OSFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// RemoveAll removes path and any children it contains.
+func (OSFS) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// Stat returns a FileInfo describing the named file.
+func (OSFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// FileContent reads the named file and returns its contents.
+func (OSFS) FileContent(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// DirEntries reads the named directory and returns its entries sorted by file
+// name.
+func (OSFS) DirEntries(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// Entry describes a file system entry.
+type Entry struct {
+	Path string
+	Perm fs.FileMode
+	Size int64 // size of the file content, 0 for directories
+	Dir  bool
+}
+
+// Mode returns file mode of the entry.
+func (e Entry) Mode() fs.FileMode {
+	if e.Dir {
+		return e.Perm | fs.ModeDir
+	}
+	return e.Perm
+}
+
+// MemFS is an in-memory writable file system. It implements fs.FS interface
+// to read written files and directories.
+//
+// Names are cleaned and stripped of the leading slash, thus a file written
+// to "/tmp/foo/main.go" is opened as "tmp/foo/main.go". Parent directories
+// that were not created explicitly are implied.
+type MemFS struct {
+	files fstest.MapFS
+	paths []string // paths of entries in the order of creation
+}
+
+// NewMemFS creates a new empty in-memory file system.
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(fstest.MapFS)}
+}
+
+// Mkdir creates a new directory with the specified name and permissions.
+func (m *MemFS) Mkdir(name string, perm fs.FileMode) error {
+	key := memKey(name)
+	if _, ok := m.files[key]; ok {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: syscall.EEXIST}
+	}
+	if err := m.checkParent(key); err != nil {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: err}
+	}
+
+	m.files[key] = &fstest.MapFile{Mode: fs.ModeDir | perm.Perm()}
+	m.paths = append(m.paths, path.Clean(name))
+	return nil
+}
+
+// WriteFile writes data to the named file, creating it if necessary.
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	key := memKey(name)
+	f, ok := m.files[key]
+	if ok && f.Mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: syscall.EISDIR}
+	}
+	if err := m.checkParent(key); err != nil {
+		return &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	m.files[key] = &fstest.MapFile{
+		Data: append([]byte(nil), data...),
+		Mode: perm.Perm(),
+	}
+	if !ok {
+		m.paths = append(m.paths, path.Clean(name))
+	}
+	return nil
+}
+
+// Rename renames (moves) oldpath with all its children to newpath.
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	oldkey, newkey := memKey(oldpath), memKey(newpath)
+	if _, ok := m.files[oldkey]; !ok {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.ENOENT}
+	}
+	if _, ok := m.files[newkey]; ok {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EEXIST}
+	}
+
+	newp := path.Clean(newpath)
+	for i, p := range m.paths {
+		key := memKey(p)
+		if rel, ok := relKey(oldkey, key); ok {
+			m.files[path.Join(newkey, rel)] = m.files[key]
+			delete(m.files, key)
+			m.paths[i] = path.Join(newp, rel)
+		}
+	}
+	return nil
+}
+
+// RemoveAll removes path and any children it contains.
+func (m *MemFS) RemoveAll(name string) error {
+	rmkey := memKey(name)
+	paths := m.paths[:0]
+	for _, p := range m.paths {
+		key := memKey(p)
+		if _, ok := relKey(rmkey, key); ok {
+			delete(m.files, key)
+			continue
+		}
+		paths = append(paths, p)
+	}
+	m.paths = paths
+	return nil
+}
+
+// Stat returns a FileInfo describing the named file.
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	fi, err := m.files.Stat(memKey(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fi, nil
+}
+
+// FileContent reads the named file and returns its contents.
+func (m *MemFS) FileContent(name string) ([]byte, error) {
+	data, err := fs.ReadFile(m.files, memKey(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: unwrapPathError(err)}
+	}
+	return data, nil
+}
+
+// DirEntries reads the named directory and returns its entries sorted by file
+// name.
+func (m *MemFS) DirEntries(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(m.files, memKey(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: unwrapPathError(err)}
+	}
+	return entries, nil
+}
+
+// Open opens the named file for reading.
+func (m *MemFS) Open(name string) (fs.File, error) {
+	return m.files.Open(name)
+}
+
+// Entries returns a list of created directories and files in the order of
+// creation.
+func (m *MemFS) Entries() []Entry {
+	entries := make([]Entry, 0, len(m.paths))
+	for _, p := range m.paths {
+		f := m.files[memKey(p)]
+		entries = append(entries, Entry{
+			Path: p,
+			Perm: f.Mode.Perm(),
+			Size: int64(len(f.Data)),
+			Dir:  f.Mode.IsDir(),
+		})
+	}
+	return entries
+}
+
+// checkParent checks that explicitly created parent of the entry is a
+// directory.
+func (m *MemFS) checkParent(key string) error {
+	for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if f, ok := m.files[dir]; ok {
+			if !f.Mode.IsDir() {
+				return syscall.ENOTDIR
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// relKey returns key relative to the parent key and true if the key is the
+// parent itself or any of its children.
+func relKey(parent, key string) (string, bool) {
+	if key == parent {
+		return ".", true
+	}
+	if strings.HasPrefix(key, parent+"/") {
+		return strings.TrimPrefix(key, parent+"/"), true
+	}
+	return "", false
+}
+
+func memKey(name string) string {
+	key := strings.TrimPrefix(path.Clean(name), "/")
+	if key == "" {
+		return "."
+	}
+	return key
+}
+
+var (
+	_ FS    = OSFS{}
+	_ FS    = (*MemFS)(nil)
+	_ fs.FS = (*MemFS)(nil)
+)
+
+func unwrapPathError(err error) error {
+	if pe, ok := err.(*fs.PathError); ok {
+		return pe.Err
+	}
+	return err
+}
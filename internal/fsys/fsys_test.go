@@ -0,0 +1,174 @@
+package fsys_test
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSFS(t *testing.T) {
+	tmpDir := t.TempDir()
+	out := fsys.OSFS{}
+
+	t.Run("creates a directory", func(t *testing.T) {
+		err := out.Mkdir(path.Join(tmpDir, "dir"), 0755)
+		require.NoError(t, err)
+
+		fi, err := os.Stat(path.Join(tmpDir, "dir"))
+		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
+	})
+
+	t.Run("writes a file with permissions", func(t *testing.T) {
+		file := path.Join(tmpDir, "dir", "run.sh")
+		err := out.WriteFile(file, []byte("echo"), 0750)
+		require.NoError(t, err)
+
+		fi, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0750), fi.Mode())
+
+		data, err := out.FileContent(file)
+		require.NoError(t, err)
+		assert.Equal(t, "echo", string(data))
+	})
+
+	t.Run("reads a directory", func(t *testing.T) {
+		entries, err := out.DirEntries(path.Join(tmpDir, "dir"))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "run.sh", entries[0].Name())
+	})
+}
+
+func TestMemFS(t *testing.T) {
+	mem := fsys.NewMemFS()
+
+	err := mem.Mkdir("/tmp/project", 0755)
+	require.NoError(t, err)
+	err = mem.Mkdir("/tmp/project/handler", 0700)
+	require.NoError(t, err)
+	err = mem.WriteFile("/tmp/project/handler/echo.go", []byte("package handler"), 0600)
+	require.NoError(t, err)
+	err = mem.WriteFile("/tmp/project/main.go", []byte("package main"), 0644)
+	require.NoError(t, err)
+
+	t.Run("returns entries in the order of creation", func(t *testing.T) {
+		expected := []fsys.Entry{
+			{Path: "/tmp/project", Perm: 0755, Dir: true},
+			{Path: "/tmp/project/handler", Perm: 0700, Dir: true},
+			{Path: "/tmp/project/handler/echo.go", Perm: 0600, Size: 15},
+			{Path: "/tmp/project/main.go", Perm: 0644, Size: 12},
+		}
+		assert.Equal(t, expected, mem.Entries())
+	})
+
+	t.Run("implements file system interface", func(t *testing.T) {
+		err := fstest.TestFS(mem, "tmp/project/handler/echo.go", "tmp/project/main.go")
+		assert.NoError(t, err)
+	})
+
+	t.Run("reads a file", func(t *testing.T) {
+		data, err := mem.FileContent("/tmp/project/handler/echo.go")
+		require.NoError(t, err)
+		assert.Equal(t, "package handler", string(data))
+
+		_, err = mem.FileContent("/tmp/project/foo.go")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.EqualError(t, err, "open /tmp/project/foo.go: file does not exist")
+	})
+
+	t.Run("reads a directory", func(t *testing.T) {
+		entries, err := mem.DirEntries("/tmp/project")
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "handler", entries[0].Name())
+		assert.True(t, entries[0].IsDir())
+		assert.Equal(t, "main.go", entries[1].Name())
+
+		_, err = mem.DirEntries("/tmp/project/foo")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("fails to create existing directory", func(t *testing.T) {
+		err := mem.Mkdir("/tmp/project/handler", 0755)
+		assert.ErrorIs(t, err, fs.ErrExist)
+	})
+
+	t.Run("fails to create directory in a file", func(t *testing.T) {
+		err := mem.Mkdir("/tmp/project/main.go/dir", 0755)
+		assert.EqualError(t, err, "mkdir /tmp/project/main.go/dir: not a directory")
+	})
+
+	t.Run("fails to write a directory", func(t *testing.T) {
+		err := mem.WriteFile("/tmp/project/handler", nil, 0644)
+		assert.EqualError(t, err, "open /tmp/project/handler: is a directory")
+	})
+
+	t.Run("overwrites a file", func(t *testing.T) {
+		err := mem.WriteFile("/tmp/project/main.go", []byte("package foo"), 0644)
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(mem, "tmp/project/main.go")
+		require.NoError(t, err)
+		assert.Equal(t, "package foo", string(data))
+		assert.Len(t, mem.Entries(), 4)
+	})
+}
+
+func TestMemFSRename(t *testing.T) {
+	mem := fsys.NewMemFS()
+	require.NoError(t, mem.Mkdir("/tmp/.staging", 0755))
+	require.NoError(t, mem.Mkdir("/tmp/.staging/handler", 0755))
+	require.NoError(t, mem.WriteFile("/tmp/.staging/handler/echo.go", []byte("package handler"), 0644))
+	require.NoError(t, mem.Mkdir("/tmp/other", 0755))
+
+	t.Run("fails when old path does not exist", func(t *testing.T) {
+		err := mem.Rename("/tmp/foo", "/tmp/bar")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("fails when new path exists", func(t *testing.T) {
+		err := mem.Rename("/tmp/.staging", "/tmp/other")
+		assert.ErrorIs(t, err, fs.ErrExist)
+	})
+
+	t.Run("moves directory with children", func(t *testing.T) {
+		err := mem.Rename("/tmp/.staging", "/tmp/project")
+		require.NoError(t, err)
+
+		expected := []fsys.Entry{
+			{Path: "/tmp/project", Perm: 0755, Dir: true},
+			{Path: "/tmp/project/handler", Perm: 0755, Dir: true},
+			{Path: "/tmp/project/handler/echo.go", Perm: 0644, Size: 15},
+			{Path: "/tmp/other", Perm: 0755, Dir: true},
+		}
+		assert.Equal(t, expected, mem.Entries())
+
+		_, err = mem.Stat("/tmp/.staging")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		fi, err := mem.Stat("/tmp/project/handler/echo.go")
+		require.NoError(t, err)
+		assert.Equal(t, int64(15), fi.Size())
+	})
+}
+
+func TestMemFSRemoveAll(t *testing.T) {
+	mem := fsys.NewMemFS()
+	require.NoError(t, mem.Mkdir("/tmp/project", 0755))
+	require.NoError(t, mem.WriteFile("/tmp/project/main.go", []byte("package main"), 0644))
+	require.NoError(t, mem.Mkdir("/tmp/project2", 0755))
+
+	err := mem.RemoveAll("/tmp/project")
+	require.NoError(t, err)
+	assert.Equal(t, []fsys.Entry{{Path: "/tmp/project2", Perm: 0755, Dir: true}}, mem.Entries())
+
+	err = mem.RemoveAll("/tmp/foo")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,2 @@
+// Package gofmt formats generated Go source code.
+package gofmt
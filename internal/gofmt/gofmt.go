@@ -0,0 +1,146 @@
+package gofmt
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Source formats Go source code in canonical gofmt style. In addition to
+// gofmt it groups imports: standard library packages first, then all other
+// packages, then packages of the local module, groups are separated by an
+// empty line.
+//
+// Imports of the local module are the local path and the paths starting with
+// it, like goimports -local does. Other imports with a dot in the first path
+// element considered non-standard.
+//
+// Source returns an error describing the line of the first syntax error when
+// the source is not a valid Go code.
+func Source(src []byte, local string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, syntaxError(err)
+	}
+
+	src = groupImports(fset, f, src, local)
+
+	res, err := format.Source(src)
+	if err != nil {
+		return nil, syntaxError(err)
+	}
+	return res, nil
+}
+
+func syntaxError(err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) == 0 {
+		return err
+	}
+
+	e := list[0]
+	msg := fmt.Sprintf("line %d: %s", e.Pos.Line, e.Msg)
+	if len(list) > 1 {
+		msg += fmt.Sprintf(" (and %d more errors)", len(list)-1)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// groupImports rewrites parenthesized import declarations of the source to
+// have standard library imports group followed by others imports group and
+// local module imports group. Declarations with comments not attached to
+// import specs are left unchanged.
+func groupImports(fset *token.FileSet, f *ast.File, src []byte, local string) []byte {
+	var res bytes.Buffer
+	last := 0
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT || !gd.Lparen.IsValid() {
+			continue
+		}
+
+		grouped, ok := groupImportSpecs(fset, f, gd, src, local)
+		if !ok {
+			continue
+		}
+
+		start := fset.Position(gd.Lparen).Offset + 1
+		end := fset.Position(gd.Rparen).Offset
+		res.Write(src[last:start])
+		res.WriteString(grouped)
+		last = end
+	}
+	res.Write(src[last:])
+	return res.Bytes()
+}
+
+func groupImportSpecs(fset *token.FileSet, f *ast.File, gd *ast.GenDecl, src []byte, local string) (string, bool) {
+	attached := 0
+	var std, other, loc []string
+	for _, s := range gd.Specs {
+		spec := s.(*ast.ImportSpec)
+		start, end := spec.Pos(), spec.End()
+		if spec.Doc != nil {
+			start = spec.Doc.Pos()
+			attached += len(spec.Doc.List)
+		}
+		if spec.Comment != nil {
+			end = spec.Comment.End()
+			attached += len(spec.Comment.List)
+		}
+		text := string(src[fset.Position(start).Offset:fset.Position(end).Offset])
+
+		p, err := strconv.Unquote(spec.Path.Value)
+		switch {
+		case err != nil:
+			other = append(other, text)
+		case isLocal(p, local):
+			loc = append(loc, text)
+		case isStd(p):
+			std = append(std, text)
+		default:
+			other = append(other, text)
+		}
+	}
+
+	if countComments(f, gd) != attached {
+		return "", false
+	}
+
+	groups := make([]string, 0, 3)
+	for _, g := range [][]string{std, other, loc} {
+		if len(g) > 0 {
+			groups = append(groups, strings.Join(g, "\n"))
+		}
+	}
+	return "\n" + strings.Join(groups, "\n\n") + "\n", true
+}
+
+func countComments(f *ast.File, gd *ast.GenDecl) int {
+	n := 0
+	for _, cg := range f.Comments {
+		if cg.Pos() > gd.Lparen && cg.End() < gd.Rparen {
+			n += len(cg.List)
+		}
+	}
+	return n
+}
+
+func isStd(p string) bool {
+	first, _, _ := strings.Cut(p, "/")
+	return !strings.Contains(first, ".")
+}
+
+func isLocal(p, local string) bool {
+	if local == "" {
+		return false
+	}
+	return p == local || strings.HasPrefix(p, local+"/")
+}
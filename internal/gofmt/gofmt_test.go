@@ -0,0 +1,167 @@
+package gofmt_test
+
+import (
+	"testing"
+
+	"github.com/antklim/chef/internal/gofmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		src      string
+		local    string
+		expected string
+	}{
+		{
+			desc:     "formats source code",
+			src:      "package foo\nfunc  Foo( ) {\nreturn }",
+			expected: "package foo\n\nfunc Foo() {\n\treturn\n}\n",
+		},
+		{
+			desc: "groups standard library imports before others",
+			src: `package http
+
+import (
+	handler "github.com/antklim/cheftest/handler/http"
+	"net/http"
+	"log"
+)
+`,
+			expected: `package http
+
+import (
+	"log"
+	"net/http"
+
+	handler "github.com/antklim/cheftest/handler/http"
+)
+`,
+		},
+		{
+			desc: "keeps comments attached to imports",
+			src: `package http
+
+import (
+	// handlers
+	handler "github.com/antklim/cheftest/handler/http"
+	"log" // logger
+)
+`,
+			expected: `package http
+
+import (
+	"log" // logger
+
+	// handlers
+	handler "github.com/antklim/cheftest/handler/http"
+)
+`,
+		},
+		{
+			desc: "does not group imports with floating comments",
+			src: `package http
+
+import (
+	handler "github.com/antklim/cheftest/handler/http"
+
+	// standard library
+
+	"log"
+)
+`,
+			expected: `package http
+
+import (
+	handler "github.com/antklim/cheftest/handler/http"
+
+	// standard library
+
+	"log"
+)
+`,
+		},
+		{
+			desc: "groups local module imports after others",
+			src: `package http
+
+import (
+	handler "github.com/antklim/cheftest/handler/http"
+	"github.com/pkg/errors"
+	"net/http"
+)
+`,
+			local: "github.com/antklim/cheftest",
+			expected: `package http
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	handler "github.com/antklim/cheftest/handler/http"
+)
+`,
+		},
+		{
+			desc: "groups dotless local module imports apart from standard library",
+			src: `package http
+
+import (
+	handler "nodot/handler/http"
+	"net/http"
+	"log"
+)
+`,
+			local: "nodot",
+			expected: `package http
+
+import (
+	"log"
+	"net/http"
+
+	handler "nodot/handler/http"
+)
+`,
+		},
+		{
+			desc:     "keeps single import",
+			src:      "package main\nimport \"fmt\"\nfunc main() { fmt.Println() }",
+			expected: "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println() }\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			res, err := gofmt.Source([]byte(tC.src), tC.local)
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, string(res))
+		})
+	}
+}
+
+func TestSourceFails(t *testing.T) {
+	testCases := []struct {
+		desc string
+		src  string
+		err  string
+	}{
+		{
+			desc: "when package clause is missing",
+			src:  "func main() {}",
+			err:  "line 1: expected 'package', found 'func'",
+		},
+		{
+			desc: "when source has multiple errors",
+			src:  "package main\n\nfunc main() {\n\tfoo(\n}\nvar = 1",
+			err:  "line 5: expected operand, found '}' (and 1 more errors)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			res, err := gofmt.Source([]byte(tC.src), "")
+			assert.EqualError(t, err, tC.err)
+			assert.Nil(t, res)
+		})
+	}
+}
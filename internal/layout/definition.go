@@ -0,0 +1,292 @@
+package layout
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateLookup returns the template registered with the given name, or nil
+// if no template found.
+type TemplateLookup func(name string) *template.Template
+
+// Definition is a declarative layout definition. For example:
+//
+//	nodes:
+//	  - dir: handler
+//	    nodes:
+//	      - dir: http
+//	        nodes:
+//	          - file: router.go
+//	            template: http_router
+//	  - dir: scripts
+//	    perm: "0700"
+//	    nodes:
+//	      - file: run.sh
+//	        perm: "0755"
+//	        body: |
+//	          #!/bin/sh
+//	          go run {{ .Module }}
+//	  - file: config.tmpl
+//	    lang: yaml
+//	    body: |
+//	      module: {{ .Module }}
+type Definition struct {
+	Nodes []NodeDefinition `yaml:"nodes"`
+}
+
+// NodeDefinition is a declarative definition of a layout node. Exactly one of
+// Dir or File should be set.
+type NodeDefinition struct {
+	Dir      string           `yaml:"dir,omitempty"`
+	File     string           `yaml:"file,omitempty"`
+	Perm     string           `yaml:"perm,omitempty"`
+	Template string           `yaml:"template,omitempty"`
+	Body     string           `yaml:"body,omitempty"`
+	Lang     string           `yaml:"lang,omitempty"` // language of the file content, by default derived from the file extension
+	Nodes    []NodeDefinition `yaml:"nodes,omitempty"`
+
+	line int // line of the node definition in the source
+}
+
+var nodeDefinitionFields = map[string]bool{
+	"dir":      true,
+	"file":     true,
+	"perm":     true,
+	"template": true,
+	"body":     true,
+	"lang":     true,
+	"nodes":    true,
+}
+
+// UnmarshalYAML decodes node definition and remembers its line in the source.
+func (nd *NodeDefinition) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: node definition must be a mapping", value.Line)
+	}
+
+	for i := 0; i < len(value.Content); i += 2 {
+		k := value.Content[i]
+		if !nodeDefinitionFields[k.Value] {
+			return fmt.Errorf("line %d: unknown field %q", k.Line, k.Value)
+		}
+	}
+
+	type plain NodeDefinition
+	if err := value.Decode((*plain)(nd)); err != nil {
+		return err
+	}
+	nd.line = value.Line
+	return nil
+}
+
+// Read reads YAML layout definition from r and creates a layout. Templates
+// referenced by file nodes are resolved using lookup, inline bodies of file
+// nodes can use funcs.
+//
+// Errors returned for invalid definitions point to the line of the offending
+// node.
+func Read(r io.Reader, lookup TemplateLookup, funcs template.FuncMap) (*Layout, error) {
+	def, err := ReadDefinition(r)
+	if err != nil {
+		return nil, err
+	}
+	return FromDefinition(def, lookup, funcs)
+}
+
+// ReadDefinition reads YAML layout definition from r. Templates referenced by
+// file nodes are not resolved.
+func ReadDefinition(r io.Reader) (Definition, error) {
+	var def Definition
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		if err == io.EOF {
+			return Definition{}, errors.New("layout definition is empty")
+		}
+		return Definition{}, errors.Wrap(err, "failed to decode layout definition")
+	}
+	return def, nil
+}
+
+// FromDefinition creates a layout from the definition. Templates referenced by
+// file nodes are resolved using lookup, inline bodies of file nodes can use
+// funcs.
+func FromDefinition(def Definition, lookup TemplateLookup, funcs template.FuncMap) (*Layout, error) {
+	nodes, err := makeNodes(def.Nodes, lookup, funcs)
+	if err != nil {
+		return nil, err
+	}
+	return New(nodes...), nil
+}
+
+// Describe creates the definition of the layout. File nodes reference their
+// templates by name when lookup finds the template, otherwise the template
+// source is used as the node body.
+func Describe(l *Layout, lookup TemplateLookup) Definition {
+	return Definition{Nodes: describeNodes(l.Nodes(), lookup)}
+}
+
+func describeNodes(nodes []node.Node, lookup TemplateLookup) []NodeDefinition {
+	defs := make([]NodeDefinition, 0, len(nodes))
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *node.Dnode:
+			defs = append(defs, NodeDefinition{
+				Dir:   n.Name(),
+				Perm:  formatPerm(n.Perm()),
+				Nodes: describeNodes(n.Nodes(), lookup),
+			})
+		case *node.Fnode:
+			d := NodeDefinition{
+				File: n.Name(),
+				Perm: formatPerm(n.Perm()),
+			}
+			if lang := n.Language(); lang != node.LanguageOf(n.Name()) {
+				d.Lang = lang
+			}
+			if tmpl := n.Template(); tmpl != nil {
+				if lookup != nil && lookup(tmpl.Name()) != nil {
+					d.Template = tmpl.Name()
+				} else if tmpl.Tree != nil {
+					d.Body = tmpl.Tree.Root.String()
+				}
+			}
+			defs = append(defs, d)
+		}
+	}
+	return defs
+}
+
+func makeNodes(defs []NodeDefinition, lookup TemplateLookup, funcs template.FuncMap) ([]node.Node, error) {
+	names := make(map[string]bool, len(defs))
+	nodes := make([]node.Node, 0, len(defs))
+	for _, d := range defs {
+		n, err := makeNode(d, lookup, funcs)
+		if err != nil {
+			return nil, err
+		}
+		if names[n.Name()] {
+			return nil, fmt.Errorf("line %d: node %q already exists", d.line, n.Name())
+		}
+		names[n.Name()] = true
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func makeNode(d NodeDefinition, lookup TemplateLookup, funcs template.FuncMap) (node.Node, error) {
+	switch {
+	case d.Dir != "" && d.File != "":
+		return nil, fmt.Errorf("line %d: node cannot be both dir %q and file %q", d.line, d.Dir, d.File)
+	case d.Dir != "":
+		return makeDnode(d, lookup, funcs)
+	case d.File != "":
+		return makeFnode(d, lookup, funcs)
+	default:
+		return nil, fmt.Errorf("line %d: node must have either dir or file name", d.line)
+	}
+}
+
+func makeDnode(d NodeDefinition, lookup TemplateLookup, funcs template.FuncMap) (node.Node, error) {
+	if err := validateNodeName(d.Dir); err != nil {
+		return nil, fmt.Errorf("line %d: dir %q: %v", d.line, d.Dir, err)
+	}
+	if d.Template != "" || d.Body != "" {
+		return nil, fmt.Errorf("line %d: dir %q cannot have template or body", d.line, d.Dir)
+	}
+	if d.Lang != "" {
+		return nil, fmt.Errorf("line %d: dir %q cannot have lang", d.line, d.Dir)
+	}
+
+	var opts []node.DnodeOption
+	if d.Perm != "" {
+		perm, err := parsePerm(d.Perm)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: dir %q: %v", d.line, d.Dir, err)
+		}
+		opts = append(opts, node.WithDperm(perm))
+	}
+
+	subnodes, err := makeNodes(d.Nodes, lookup, funcs)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, node.WithSubNodes(subnodes...))
+
+	return node.NewDnode(d.Dir, opts...), nil
+}
+
+func makeFnode(d NodeDefinition, lookup TemplateLookup, funcs template.FuncMap) (node.Node, error) {
+	if err := validateNodeName(d.File); err != nil {
+		return nil, fmt.Errorf("line %d: file %q: %v", d.line, d.File, err)
+	}
+	if len(d.Nodes) > 0 {
+		return nil, fmt.Errorf("line %d: file %q cannot have subnodes", d.line, d.File)
+	}
+
+	var opts []node.FnodeOption
+	if d.Perm != "" {
+		perm, err := parsePerm(d.Perm)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: file %q: %v", d.line, d.File, err)
+		}
+		opts = append(opts, node.WithFperm(perm))
+	}
+	if d.Lang != "" {
+		if !node.KnownLanguage(d.Lang) {
+			return nil, fmt.Errorf("line %d: file %q: unknown language %q", d.line, d.File, d.Lang)
+		}
+		opts = append(opts, node.WithLanguage(d.Lang))
+	}
+
+	switch {
+	case d.Template != "" && d.Body != "":
+		return nil, fmt.Errorf("line %d: file %q cannot have both template and body", d.line, d.File)
+	case d.Template != "":
+		var tmpl *template.Template
+		if lookup != nil {
+			tmpl = lookup(d.Template)
+		}
+		if tmpl == nil {
+			return nil, fmt.Errorf("line %d: file %q: unknown template %q", d.line, d.File, d.Template)
+		}
+		opts = append(opts, node.WithTemplate(tmpl))
+	case d.Body != "":
+		tmpl, err := template.New(d.File).Funcs(funcs).Parse(d.Body)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: file %q: invalid body: %v", d.line, d.File, err)
+		}
+		opts = append(opts, node.WithTemplate(tmpl))
+	default:
+		return nil, fmt.Errorf("line %d: file %q must have template or body", d.line, d.File)
+	}
+
+	return node.NewFnode(d.File, opts...), nil
+}
+
+func validateNodeName(name string) error {
+	if name == Root || name == ".." || strings.ContainsAny(name, `/\`) {
+		return errors.New("invalid node name")
+	}
+	return nil
+}
+
+func formatPerm(p fs.FileMode) string {
+	return fmt.Sprintf("%04o", uint32(p.Perm()))
+}
+
+func parsePerm(s string) (fs.FileMode, error) {
+	p, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || p > uint64(fs.ModePerm) {
+		return 0, fmt.Errorf("invalid permissions %q", s)
+	}
+	return fs.FileMode(p), nil
+}
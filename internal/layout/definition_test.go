@@ -0,0 +1,269 @@
+package layout_test
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/layout"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTemplates = map[string]*template.Template{
+	"http_router": template.Must(template.New("http_router").Parse("package http")),
+}
+
+func testLookup(name string) *template.Template {
+	return testTemplates[name]
+}
+
+func TestReadLayout(t *testing.T) {
+	def := `nodes:
+  - dir: handler
+    nodes:
+      - dir: http
+        nodes:
+          - file: router.go
+            template: http_router
+  - dir: scripts
+    perm: 0700
+    nodes:
+      - file: run.sh
+        perm: "0755"
+        body: |
+          go run {{ .Module }}
+  - file: README.md
+    body: "# {{ .Module }}"
+`
+	l, err := layout.Read(strings.NewReader(def), testLookup, nil)
+	require.NoError(t, err)
+
+	expectedNodes := []string{"handler", "handler/http", "handler/http/router.go", "scripts", "scripts/run.sh", "README.md"}
+	for _, loc := range expectedNodes {
+		assert.NotNil(t, l.FindNode(loc), loc)
+	}
+
+	assert.IsType(t, &node.Dnode{}, l.FindNode("scripts"))
+	assert.IsType(t, &node.Fnode{}, l.FindNode("scripts/run.sh"))
+
+	t.Run("builds nodes with inline templates", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		err := l.Build(mem, "/tmp", struct{ Module string }{Module: "cheftest"})
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(mem, "tmp/scripts/run.sh")
+		require.NoError(t, err)
+		assert.Equal(t, "go run cheftest\n", string(data))
+
+		fi, err := fs.Stat(mem, "tmp/scripts/run.sh")
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0755), fi.Mode())
+	})
+}
+
+func TestReadLayoutWithFuncs(t *testing.T) {
+	def := `nodes:
+  - file: README.md
+    body: "# {{ .Module | base }}"
+`
+	funcs := template.FuncMap{"base": path.Base}
+	l, err := layout.Read(strings.NewReader(def), testLookup, funcs)
+	require.NoError(t, err)
+
+	mem := fsys.NewMemFS()
+	err = l.Build(mem, "/tmp", struct{ Module string }{Module: "github.com/antklim/cheftest"})
+	require.NoError(t, err)
+
+	data, err := fs.ReadFile(mem, "tmp/README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# cheftest", string(data))
+
+	t.Run("fails when body uses unknown function", func(t *testing.T) {
+		_, err := layout.Read(strings.NewReader(def), testLookup, nil)
+		assert.EqualError(t, err, `line 2: file "README.md": invalid body: template: README.md:1: function "base" not defined`)
+	})
+}
+
+func TestReadLayoutFails(t *testing.T) {
+	testCases := []struct {
+		desc string
+		def  string
+		err  string
+	}{
+		{
+			desc: "when definition is empty",
+			def:  "",
+			err:  "layout definition is empty",
+		},
+		{
+			desc: "when definition has unknown top level field",
+			def:  "foo: bar",
+			err:  "failed to decode layout definition: yaml: unmarshal errors:\n  line 1: field foo not found in type layout.Definition",
+		},
+		{
+			desc: "when node has unknown field",
+			def: `nodes:
+  - dir: handler
+    mode: 0755`,
+			err: `failed to decode layout definition: line 3: unknown field "mode"`,
+		},
+		{
+			desc: "when node is not a mapping",
+			def: `nodes:
+  - handler`,
+			err: "failed to decode layout definition: line 2: node definition must be a mapping",
+		},
+		{
+			desc: "when node has neither dir nor file",
+			def: `nodes:
+  - perm: "0755"`,
+			err: "line 2: node must have either dir or file name",
+		},
+		{
+			desc: "when node has both dir and file",
+			def: `nodes:
+  - dir: handler
+    file: handler.go`,
+			err: `line 2: node cannot be both dir "handler" and file "handler.go"`,
+		},
+		{
+			desc: "when node name is invalid",
+			def: `nodes:
+  - dir: handler/http`,
+			err: `line 2: dir "handler/http": invalid node name`,
+		},
+		{
+			desc: "when permissions are invalid",
+			def: `nodes:
+  - dir: handler
+    perm: rwx`,
+			err: `line 2: dir "handler": invalid permissions "rwx"`,
+		},
+		{
+			desc: "when dir has template",
+			def: `nodes:
+  - dir: handler
+    template: http_router`,
+			err: `line 2: dir "handler" cannot have template or body`,
+		},
+		{
+			desc: "when file has subnodes",
+			def: `nodes:
+  - file: main.go
+    body: package main
+    nodes:
+      - dir: handler`,
+			err: `line 2: file "main.go" cannot have subnodes`,
+		},
+		{
+			desc: "when file has no template",
+			def: `nodes:
+  - dir: handler
+    nodes:
+      - file: main.go`,
+			err: `line 4: file "main.go" must have template or body`,
+		},
+		{
+			desc: "when file has both template and body",
+			def: `nodes:
+  - file: main.go
+    template: http_router
+    body: package main`,
+			err: `line 2: file "main.go" cannot have both template and body`,
+		},
+		{
+			desc: "when file references unknown template",
+			def: `nodes:
+  - dir: handler
+  - file: main.go
+    template: foo`,
+			err: `line 3: file "main.go": unknown template "foo"`,
+		},
+		{
+			desc: "when file has invalid body",
+			def: `nodes:
+  - file: main.go
+    body: "{{ .Module"`,
+			err: `line 2: file "main.go": invalid body: template: main.go:1: unclosed action`,
+		},
+		{
+			desc: "when file has unknown language",
+			def: `nodes:
+  - file: main.rs
+    lang: rust
+    body: fn main() {}`,
+			err: `line 2: file "main.rs": unknown language "rust"`,
+		},
+		{
+			desc: "when dir has language",
+			def: `nodes:
+  - dir: handler
+    lang: go`,
+			err: `line 2: dir "handler" cannot have lang`,
+		},
+		{
+			desc: "when node names are duplicated",
+			def: `nodes:
+  - dir: handler
+  - file: handler
+    body: foo`,
+			err: `line 3: node "handler" already exists`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			l, err := layout.Read(bytes.NewBufferString(tC.def), testLookup, nil)
+			assert.EqualError(t, err, tC.err)
+			assert.Nil(t, l)
+		})
+	}
+}
+
+func TestDescribeLayout(t *testing.T) {
+	l := layout.New(
+		node.NewDnode("handler", node.WithSubNodes(
+			node.NewDnode("http", node.WithSubNodes(
+				node.NewFnode("router.go", node.WithTemplate(testTemplates["http_router"])))))),
+		node.NewDnode("scripts", node.WithDperm(0700), node.WithSubNodes(
+			node.NewFnode("run.sh", node.WithFperm(0755), node.WithNewTemplate("run.sh", "go run {{ .Module }}\n")))),
+		node.NewFnode("config.tmpl", node.WithLanguage(node.LangYAML), node.WithNewTemplate("config.tmpl", "module: {{ .Module }}\n")),
+	)
+
+	def := layout.Describe(l, testLookup)
+	expected := layout.Definition{Nodes: []layout.NodeDefinition{
+		{Dir: "handler", Perm: "0755", Nodes: []layout.NodeDefinition{
+			{Dir: "http", Perm: "0755", Nodes: []layout.NodeDefinition{
+				{File: "router.go", Perm: "0644", Template: "http_router"},
+			}},
+		}},
+		{Dir: "scripts", Perm: "0700", Nodes: []layout.NodeDefinition{
+			{File: "run.sh", Perm: "0755", Body: "go run {{.Module}}\n"},
+		}},
+		{File: "config.tmpl", Perm: "0644", Body: "module: {{.Module}}\n", Lang: "yaml"},
+	}}
+	assert.Equal(t, expected, def)
+
+	t.Run("creates the same layout from definition", func(t *testing.T) {
+		dl, err := layout.FromDefinition(def, testLookup, nil)
+		require.NoError(t, err)
+
+		mem := fsys.NewMemFS()
+		err = dl.Build(mem, "/tmp", struct{ Module string }{Module: "cheftest"})
+		require.NoError(t, err)
+
+		expected := fsys.NewMemFS()
+		err = l.Build(expected, "/tmp", struct{ Module string }{Module: "cheftest"})
+		require.NoError(t, err)
+		assert.Equal(t, expected.Entries(), mem.Entries())
+
+		data, err := fs.ReadFile(mem, "tmp/scripts/run.sh")
+		require.NoError(t, err)
+		assert.Equal(t, "go run cheftest\n", string(data))
+	})
+}
@@ -2,8 +2,10 @@ package layout
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/pkg/errors"
 )
@@ -47,18 +49,40 @@ func (l *Layout) AddNode(n node.Node, loc string) error {
 	return nil
 }
 
-// Build recursively builds all nodes in layout.
-func (l *Layout) Build(loc, mod string) error {
-	data := struct {
-		Module string
-	}{
-		Module: mod,
+// RemoveNode removes a node at the location from the layout.
+func (l *Layout) RemoveNode(loc string) error {
+	dir, name := path.Split(loc)
+	dir = path.Clean(dir)
+
+	locNode := l.FindNode(dir)
+	if locNode == nil {
+		return fmt.Errorf("%q not found in layout", dir)
+	}
+
+	locDir, ok := locNode.(node.Remover)
+	if !ok {
+		return fmt.Errorf("%q cannot have subnodes", dir)
 	}
 
+	if err := locDir.Remove(name); err != nil {
+		return errors.Wrapf(err, "failed to remove node from %q", dir)
+	}
+	return nil
+}
+
+// Nodes returns nodes of the layout root.
+func (l *Layout) Nodes() []node.Node {
+	return l.rootDir().Nodes()
+}
+
+// Build recursively builds all nodes in layout in the location of the file
+// system. Node templates are executed with data. The returned error names the
+// failed node.
+func (l *Layout) Build(out fsys.FS, loc string, data interface{}) error {
 	root := l.rootDir()
 	for _, n := range root.Nodes() {
-		if err := n.Build(loc, data); err != nil {
-			return err
+		if err := n.Build(out, loc, data); err != nil {
+			return errors.Wrapf(err, "failed to build node %q", n.Name())
 		}
 	}
 	return nil
@@ -1,11 +1,14 @@
 package layout_test
 
 import (
+	"io/fs"
 	"testing"
 
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewLayout(t *testing.T) {
@@ -34,7 +37,7 @@ func TestLayoutBuild(t *testing.T) {
 			desc: "fails when node build fails",
 			node: newTestNode("bar"),
 			loc:  "/error/bar",
-			err:  "node build error",
+			err:  `failed to build node "bar": node build error`,
 		},
 	}
 	for _, tC := range testCases {
@@ -42,7 +45,7 @@ func TestLayoutBuild(t *testing.T) {
 			l := layout.New(tC.node)
 			assert.False(t, tC.node.WasBuild())
 
-			err := l.Build(tC.loc, "module_name")
+			err := l.Build(fsys.NewMemFS(), tC.loc, "module_name")
 			if tC.err != "" {
 				assert.EqualError(t, err, tC.err)
 			} else {
@@ -55,6 +58,25 @@ func TestLayoutBuild(t *testing.T) {
 	}
 }
 
+func TestLayoutBuildFS(t *testing.T) {
+	f := node.NewFnode("main.go", node.WithNewTemplate("test", "package {{ .Module }}"))
+	l := layout.New(node.NewDnode("dir"), f)
+
+	mem := fsys.NewMemFS()
+	err := l.Build(mem, "/tmp/foo", struct{ Module string }{Module: "bar"})
+	require.NoError(t, err)
+
+	expected := []fsys.Entry{
+		{Path: "/tmp/foo/dir", Perm: 0755, Dir: true},
+		{Path: "/tmp/foo/main.go", Perm: 0644, Size: 12},
+	}
+	assert.Equal(t, expected, mem.Entries())
+
+	data, err := fs.ReadFile(mem, "tmp/foo/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package bar\n", string(data))
+}
+
 func TestLayoutAddNodeFails(t *testing.T) {
 	/* Test layout:
 	  .
@@ -128,6 +150,62 @@ func TestLayoutAddNode(t *testing.T) {
 	})
 }
 
+func TestLayoutRemoveNode(t *testing.T) {
+	/* Test layout:
+	  .
+		+- dir
+		   +- file.txt
+		+- main.go
+	*/
+
+	f := node.NewFnode("file.txt")
+	d := node.NewDnode("dir", node.WithSubNodes(f))
+	l := layout.New(d, node.NewFnode("main.go"))
+
+	testCases := []struct {
+		desc string
+		loc  string
+		err  string
+	}{
+		{
+			desc: "fails when nested level is a file",
+			loc:  "dir/file.txt/foo",
+			err:  `"dir/file.txt" cannot have subnodes`,
+		},
+		{
+			desc: "fails when nested level not found in layout",
+			loc:  "other/file.txt",
+			err:  `"other" not found in layout`,
+		},
+		{
+			desc: "fails when node not found",
+			loc:  "dir/other.txt",
+			err:  `failed to remove node from "dir": node "other.txt" not found`,
+		},
+		{
+			desc: "removes nested node",
+			loc:  "dir/file.txt",
+		},
+		{
+			desc: "removes root level node",
+			loc:  "main.go",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := l.RemoveNode(tC.loc)
+			if tC.err != "" {
+				assert.EqualError(t, err, tC.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Nil(t, l.FindNode(tC.loc))
+		})
+	}
+
+	assert.Equal(t, []node.Node{d}, l.Nodes())
+}
+
 func TestLayoutFindNode(t *testing.T) {
 	/* Test layout:
 	  .
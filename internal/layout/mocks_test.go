@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout/node"
 )
 
@@ -18,7 +19,7 @@ func newTestNode(name string) *testNode {
 	return &testNode{name: name}
 }
 
-func (n *testNode) Build(loc string, _ interface{}) error {
+func (n *testNode) Build(_ fsys.FS, loc string, _ interface{}) error {
 	n.buildCalled = true
 	n.loc = loc
 	if strings.HasPrefix(loc, "/error") {
@@ -1,14 +1,17 @@
 package node
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/fs"
-	"os"
 	"path"
 	"text/template"
 
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/gofmt"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 )
 
 // TODO (feat): consider to store location in node and remove it from Build
@@ -22,6 +25,65 @@ var (
 	errNilTemplate = errors.New("node template is nil")
 )
 
+// Languages of the file nodes content.
+const (
+	LangGo       = "go"
+	LangProto    = "proto"
+	LangSQL      = "sql"
+	LangYAML     = "yaml"
+	LangMarkdown = "markdown"
+	LangText     = "text"
+)
+
+// langs maps file extensions to the languages of the file content.
+var langs = map[string]string{
+	".go":    LangGo,
+	".proto": LangProto,
+	".sql":   LangSQL,
+	".yaml":  LangYAML,
+	".yml":   LangYAML,
+	".md":    LangMarkdown,
+	".txt":   LangText,
+}
+
+// formatters maps languages to formatters of the rendered file content. The
+// formatters receive the Go module of the build data.
+var formatters = map[string]func([]byte, string) ([]byte, error){
+	LangGo:   gofmt.Source,
+	LangYAML: checkYAML,
+}
+
+// ModuleData is implemented by the build data of Go projects. Imports of the
+// module packages are grouped after other imports in formatted Go files.
+type ModuleData interface {
+	ModulePath() string
+}
+
+// KnownLanguage reports whether the language is supported.
+func KnownLanguage(lang string) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// LanguageOf returns the language of the file content derived from the file
+// extension, or an empty string when the extension is unknown.
+func LanguageOf(name string) string {
+	return langs[path.Ext(name)]
+}
+
+// checkYAML checks that src is a valid YAML document.
+func checkYAML(src []byte, _ string) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(src, &v); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
 // Adder is the interface that wraps node Add method.
 //
 // Add adds Node to a collection of subnodes.
@@ -30,6 +92,14 @@ type Adder interface {
 	Add(Node) error
 }
 
+// Remover is the interface that wraps node Remove method.
+//
+// Remove removes Node by provided name from the collection of subnodes.
+// It returns an error if no nodes found.
+type Remover interface {
+	Remove(string) error
+}
+
 // Getter is the interface that wraps node Get method.
 //
 // Get searches node by provided name in the collection of subnodes.
@@ -42,8 +112,8 @@ type Getter interface {
 type Node interface {
 	// Name returns a node name.
 	Name() string
-	// Build executes node build.
-	Build(loc string, data interface{}) error
+	// Build executes node build in the location of the file system.
+	Build(out fsys.FS, loc string, data interface{}) error
 }
 
 type node struct {
@@ -78,19 +148,24 @@ func (n *Dnode) Name() string {
 	return n.name
 }
 
+// Perm returns directory permissions.
+func (n *Dnode) Perm() fs.FileMode {
+	return n.permissions
+}
+
 // Build creates a directory in file system recursively builds all subnodes.
 //
 // When subnode build fails the process stops and the error is returned.
 // Node directory is not deleted in case of build failure.
-func (n *Dnode) Build(loc string, data interface{}) error {
+func (n *Dnode) Build(out fsys.FS, loc string, data interface{}) error {
 	o := path.Join(loc, n.Name())
 
-	if err := os.Mkdir(o, n.permissions); err != nil {
+	if err := out.Mkdir(o, n.permissions); err != nil {
 		return err
 	}
 
 	for _, sn := range n.subnodes {
-		if err := sn.Build(o, data); err != nil {
+		if err := sn.Build(out, o, data); err != nil {
 			return errors.Wrapf(err, "failed to build subnode %q", sn.Name())
 		}
 	}
@@ -120,6 +195,19 @@ func (n *Dnode) Add(newNode Node) error {
 	return nil
 }
 
+// Remove removes a node from a list of subnodes.
+//
+// When subnode list does not have a node with the name the error returned.
+func (n *Dnode) Remove(name string) error {
+	for i, sn := range n.subnodes {
+		if sn.Name() == name {
+			n.subnodes = append(n.subnodes[:i:i], n.subnodes[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("node %q not found", name)
+}
+
 func findByName(nodes []Node, n string) Node {
 	for _, node := range nodes {
 		if node.Name() == n {
@@ -164,6 +252,7 @@ func WithDperm(p fs.FileMode) DnodeOption {
 type Fnode struct {
 	node
 	template *template.Template
+	lang     string // language of the file content, by default derived from the file extension
 }
 
 // NewFnode creates a new file node.
@@ -187,27 +276,68 @@ func (n *Fnode) Name() string {
 	return n.name
 }
 
+// Perm returns file permissions.
+func (n *Fnode) Perm() fs.FileMode {
+	return n.permissions
+}
+
+// Template returns node template.
+func (n *Fnode) Template() *template.Template {
+	return n.template
+}
+
 // Build executes node template and writes it to a file to a provided location.
-func (n *Fnode) Build(loc string, data interface{}) error {
-	if n.template == nil {
-		return errNilTemplate
+// Rendered content of files with known extensions (for example Go source
+// files) is formatted. The file is not created when template execution or
+// formatting fails.
+func (n *Fnode) Build(out fsys.FS, loc string, data interface{}) error {
+	content, err := n.Render(data)
+	if err != nil {
+		return err
 	}
 
 	o := path.Join(loc, n.Name())
+	return out.WriteFile(o, content, n.permissions)
+}
 
-	f, err := os.Create(o)
-	if err != nil {
-		return err
+// Render executes node template with data and returns the file content the node
+// builds.
+func (n *Fnode) Render(data interface{}) ([]byte, error) {
+	if n.template == nil {
+		return nil, errNilTemplate
 	}
-	if err := n.wbuild(f, data); err != nil {
-		f.Close()
-		// Remove created file. It's a clean up, thus ignore errors here.
-		os.Remove(o)
-		return errors.Wrap(err, "failed to execute template")
+
+	var buf bytes.Buffer
+	if err := n.wbuild(&buf, data); err != nil {
+		return nil, errors.Wrap(err, "failed to execute template")
 	}
-	defer f.Close()
 
-	return f.Chmod(n.permissions)
+	var module string
+	if md, ok := data.(ModuleData); ok {
+		module = md.ModulePath()
+	}
+	return n.format(buf.Bytes(), module)
+}
+
+// Language returns language of the file content.
+func (n *Fnode) Language() string {
+	if n.lang != "" {
+		return n.lang
+	}
+	return LanguageOf(n.Name())
+}
+
+func (n *Fnode) format(src []byte, module string) ([]byte, error) {
+	f, ok := formatters[n.Language()]
+	if !ok {
+		return src, nil
+	}
+
+	res, err := f(src, module)
+	if err != nil {
+		return nil, errors.Wrapf(err, "template %q rendered invalid code", n.template.Name())
+	}
+	return res, nil
 }
 
 func (n *Fnode) wbuild(w io.Writer, data interface{}) error {
@@ -239,10 +369,14 @@ func WithFperm(p fs.FileMode) FnodeOption {
 }
 
 // WithNewTemplate adds node template with template name tn and template string
-// ts.
-func WithNewTemplate(tn, ts string) FnodeOption {
+// ts. The template string can use funcs.
+func WithNewTemplate(tn, ts string, funcs ...template.FuncMap) FnodeOption {
 	return newfnodefopt(func(n *Fnode) {
-		n.template = template.Must(template.New(tn).Parse(ts))
+		t := template.New(tn)
+		for _, fm := range funcs {
+			t = t.Funcs(fm)
+		}
+		n.template = template.Must(t.Parse(ts))
 	})
 }
 
@@ -252,3 +386,11 @@ func WithTemplate(t *template.Template) FnodeOption {
 		n.template = t
 	})
 }
+
+// WithLanguage returns an FnodeOption that sets language of the file content.
+// The content is formatted according to the language when the node is built.
+func WithLanguage(lang string) FnodeOption {
+	return newfnodefopt(func(n *Fnode) {
+		n.lang = lang
+	})
+}
@@ -1,11 +1,14 @@
 package node_test
 
 import (
+	"io/fs"
 	"os"
 	"path"
 	"strings"
 	"testing"
+	"text/template"
 
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,84 +69,209 @@ func TestDnodeAdd(t *testing.T) {
 	})
 }
 
+func TestDnodeRemove(t *testing.T) {
+	f1 := node.NewFnode("file1.txt")
+	f2 := node.NewFnode("file2.txt")
+	d := node.NewDnode("dir", node.WithSubNodes(f1, f2))
+
+	t.Run("returns an error when sub node not found", func(t *testing.T) {
+		err := d.Remove("file3.txt")
+		assert.EqualError(t, err, `node "file3.txt" not found`)
+		assert.Len(t, d.Nodes(), 2)
+	})
+
+	t.Run("removes a subnode", func(t *testing.T) {
+		err := d.Remove("file1.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, []node.Node{f2}, d.Nodes())
+	})
+}
+
 func TestDnodeBuild(t *testing.T) {
 	t.Run("creates node directory in a provided location", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		d := node.NewDnode("dir")
-		err := d.Build(tmpDir, "module_name")
+		mem := fsys.NewMemFS()
+		d := node.NewDnode("dir", node.WithDperm(0700))
+		err := d.Build(mem, "/tmp", "module_name")
 		require.NoError(t, err)
 
-		_, err = os.ReadDir(path.Join(tmpDir, d.Name()))
-		assert.NoError(t, err)
+		expected := []fsys.Entry{{Path: "/tmp/dir", Perm: 0700, Dir: true}}
+		assert.Equal(t, expected, mem.Entries())
 	})
 
 	t.Run("creates a directory subnode", func(t *testing.T) {
-		tmpDir := t.TempDir()
+		mem := fsys.NewMemFS()
 		sd := node.NewDnode("subdir")
 		d := node.NewDnode("dir", node.WithSubNodes(sd))
-		err := d.Build(tmpDir, "module_name")
+		err := d.Build(mem, "/tmp", "module_name")
 		require.NoError(t, err)
 
-		_, err = os.ReadDir(path.Join(tmpDir, d.Name(), sd.Name()))
+		fi, err := fs.Stat(mem, "tmp/dir/subdir")
 		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
 	})
 
 	t.Run("creates a file subnode", func(t *testing.T) {
-		tmpDir := t.TempDir()
+		mem := fsys.NewMemFS()
 		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo"))
 		d := node.NewDnode("dir", node.WithSubNodes(f))
-		err := d.Build(tmpDir, "module_name")
+		err := d.Build(mem, "/tmp", "module_name")
 		require.NoError(t, err)
 
-		_, err = os.ReadFile(path.Join(tmpDir, d.Name(), f.Name()))
+		_, err = fs.ReadFile(mem, "tmp/dir/file.go")
 		require.NoError(t, err)
 	})
 
 	t.Run("fails when subnode build fails", func(t *testing.T) {
-		tmpDir := t.TempDir()
+		mem := fsys.NewMemFS()
 		f := node.NewFnode("file.go")
 		d := node.NewDnode("dir", node.WithSubNodes(f))
-		err := d.Build(tmpDir, "module_name")
+		err := d.Build(mem, "/tmp", "module_name")
 		require.EqualError(t, err, `failed to build subnode "file.go": node template is nil`)
 
-		_, err = os.ReadFile(path.Join(tmpDir, d.Name(), f.Name()))
-		assert.True(t, os.IsNotExist(err))
+		_, err = fs.ReadFile(mem, "tmp/dir/file.go")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("fails when directory exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		d := node.NewDnode("dir")
+		err := d.Build(fsys.OSFS{}, tmpDir, "module_name")
+		require.NoError(t, err)
+
+		err = d.Build(fsys.OSFS{}, tmpDir, "module_name")
+		assert.ErrorIs(t, err, fs.ErrExist)
 	})
 }
 
 func TestFnodeBuild(t *testing.T) {
 	t.Run("fails when does not have template", func(t *testing.T) {
-		tmpDir := t.TempDir()
+		mem := fsys.NewMemFS()
 		f := node.NewFnode("file.go")
-		err := f.Build(tmpDir, "module_name")
+		err := f.Build(mem, "/tmp", "module_name")
 		assert.EqualError(t, err, "node template is nil")
-
-		_, err = os.ReadFile(path.Join(tmpDir, f.Name()))
-		assert.True(t, os.IsNotExist(err))
+		assert.Empty(t, mem.Entries())
 	})
 
 	t.Run("fails when cannot execute template", func(t *testing.T) {
-		tmpDir := t.TempDir()
+		mem := fsys.NewMemFS()
 		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo {{ .Foo }}"))
-		err := f.Build(tmpDir, "module_name")
+		err := f.Build(mem, "/tmp", "module_name")
 		assert.Error(t, err)
 		isValidError := strings.HasPrefix(err.Error(), "failed to execute template")
 		assert.True(t, isValidError)
+		assert.Empty(t, mem.Entries())
+	})
+
+	t.Run("fails when template renders invalid Go code", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo\n\nfunc {{ .Module }}() {}"))
+		err := f.Build(mem, "/tmp", struct{ Module string }{Module: "module-name"})
+		assert.EqualError(t, err, `template "test" rendered invalid code: line 3: expected '(', found '-'`)
+		assert.Empty(t, mem.Entries())
+	})
+
+	t.Run("executes template with functions", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		funcs := template.FuncMap{"base": path.Base}
+		f := node.NewFnode("README.md", node.WithNewTemplate("test", "# {{ .Module | base }}", funcs))
+		err := f.Build(mem, "/tmp", struct{ Module string }{Module: "github.com/antklim/cheftest"})
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(mem, "tmp/README.md")
+		require.NoError(t, err)
+		assert.Equal(t, "# cheftest", string(data))
+	})
+
+	t.Run("formats rendered Go code", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo\nimport (\n\"github.com/foo/bar\"\n\"fmt\"\n)\nvar _ = fmt.Sprint\nvar _ = bar.X"))
+		err := f.Build(mem, "/tmp", nil)
+		require.NoError(t, err)
+
+		expected := "package foo\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/foo/bar\"\n)\n\nvar _ = fmt.Sprint\nvar _ = bar.X\n"
+		data, err := fs.ReadFile(mem, "tmp/file.go")
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("does not format files other than Go", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("file.txt", node.WithNewTemplate("test", "package   foo"))
+		err := f.Build(mem, "/tmp", nil)
+		require.NoError(t, err)
 
-		_, err = os.ReadFile(path.Join(tmpDir, f.Name()))
-		assert.True(t, os.IsNotExist(err))
+		data, err := fs.ReadFile(mem, "tmp/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "package   foo", string(data))
+	})
+
+	t.Run("formats rendered code of the node language", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("file.go.tmpl", node.WithLanguage(node.LangGo), node.WithNewTemplate("test", "package   foo"))
+		err := f.Build(mem, "/tmp", nil)
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(mem, "tmp/file.go.tmpl")
+		require.NoError(t, err)
+		assert.Equal(t, "package foo\n", string(data))
+	})
+
+	t.Run("fails when template renders invalid YAML", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("config.yaml", node.WithNewTemplate("test", "name: {{ .Module }}\n  key: value"))
+		err := f.Build(mem, "/tmp", struct{ Module string }{Module: "cheftest"})
+		assert.ErrorContains(t, err, `template "test" rendered invalid code: yaml:`)
+		assert.Empty(t, mem.Entries())
 	})
 
 	t.Run("creates a file using node template", func(t *testing.T) {
+		mem := fsys.NewMemFS()
+		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo"), node.WithFperm(0600))
+		err := f.Build(mem, "/tmp", "module_name")
+		require.NoError(t, err)
+
+		expected := "package foo\n"
+
+		data, err := fs.ReadFile(mem, "tmp/file.go")
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+
+		fi, err := fs.Stat(mem, "tmp/file.go")
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0600), fi.Mode())
+	})
+
+	t.Run("creates a file in the OS file system", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		f := node.NewFnode("file.go", node.WithNewTemplate("test", "package foo"))
-		err := f.Build(tmpDir, "module_name")
+		err := f.Build(fsys.OSFS{}, tmpDir, "module_name")
 		require.NoError(t, err)
 
-		expected := "package foo"
-
 		data, err := os.ReadFile(path.Join(tmpDir, f.Name()))
 		require.NoError(t, err)
-		assert.Equal(t, expected, string(data))
+		assert.Equal(t, "package foo\n", string(data))
 	})
 }
+
+func TestFnodeLanguage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []node.FnodeOption
+		expected string
+	}{
+		{name: "main.go", expected: node.LangGo},
+		{name: "main_test.go", expected: node.LangGo},
+		{name: "service.proto", expected: node.LangProto},
+		{name: "001_users.up.sql", expected: node.LangSQL},
+		{name: "config.yml", expected: node.LangYAML},
+		{name: "README.md", expected: node.LangMarkdown},
+		{name: "Makefile", expected: ""},
+		{name: "Makefile", opts: []node.FnodeOption{node.WithLanguage(node.LangText)}, expected: node.LangText},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			f := node.NewFnode(tC.name, tC.opts...)
+			assert.Equal(t, tC.expected, f.Language())
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package merge implements line based three-way merge of text files.
+package merge
@@ -0,0 +1,184 @@
+package merge
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Conflict markers surround the conflicting lines of the merged sides.
+const (
+	startMarker = "<<<<<<<"
+	sepMarker   = "======="
+	endMarker   = ">>>>>>>"
+)
+
+// Labels names the merged sides in conflict markers.
+type Labels struct {
+	Ours   string
+	Theirs string
+}
+
+// Merge merges the changes ours and theirs made to their common ancestor base.
+// Lines changed on one side only are taken from that side, lines changed the
+// same way on both sides are taken once. Lines changed differently are
+// written between conflict markers. It returns the merged content and the
+// number of conflicts.
+func Merge(base, ours, theirs []byte, l Labels) ([]byte, int) {
+	return merge(lines(base), lines(ours), lines(theirs), l, false)
+}
+
+// MergeWithoutBase merges ours and theirs that do not have a known common
+// ancestor. The lines both sides have in common are kept, every difference is
+// written between conflict markers. It returns the merged content and the
+// number of conflicts.
+func MergeWithoutBase(ours, theirs []byte, l Labels) ([]byte, int) {
+	o, t := lines(ours), lines(theirs)
+
+	var base []string
+	for i, j := range match(o, t) {
+		if j != -1 {
+			base = append(base, o[i])
+		}
+	}
+	return merge(base, o, t, l, true)
+}
+
+func merge(base, ours, theirs []string, l Labels, conflictAll bool) ([]byte, int) {
+	mo, mt := match(base, ours), match(base, theirs)
+
+	var out bytes.Buffer
+	var conflicts int
+	i, o, t := 0, 0, 0
+	for i < len(base) || o < len(ours) || t < len(theirs) {
+		// the base line kept by both sides
+		if i < len(base) && mo[i] == o && mt[i] == t {
+			out.WriteString(base[i])
+			i, o, t = i+1, o+1, t+1
+			continue
+		}
+
+		// the changed chunk lasts until the next base line kept by both sides
+		j := i
+		for j < len(base) && (mo[j] == -1 || mt[j] == -1) {
+			j++
+		}
+		oe, te := len(ours), len(theirs)
+		if j < len(base) {
+			oe, te = mo[j], mt[j]
+		}
+
+		b, oc, tc := base[i:j], ours[o:oe], theirs[t:te]
+		switch {
+		case slices.Equal(oc, tc):
+			writeLines(&out, oc)
+		case !conflictAll && slices.Equal(oc, b):
+			writeLines(&out, tc)
+		case !conflictAll && slices.Equal(tc, b):
+			writeLines(&out, oc)
+		default:
+			writeConflict(&out, oc, tc, l)
+			conflicts++
+		}
+		i, o, t = j, oe, te
+	}
+	return out.Bytes(), conflicts
+}
+
+func writeLines(out *bytes.Buffer, ls []string) {
+	for _, l := range ls {
+		out.WriteString(l)
+	}
+}
+
+// writeConflict writes the conflicting lines between conflict markers. The
+// markers are always on their own lines.
+func writeConflict(out *bytes.Buffer, ours, theirs []string, l Labels) {
+	writeMarker(out, startMarker, l.Ours)
+	writeLines(out, ours)
+	endLine(out)
+	writeMarker(out, sepMarker, "")
+	writeLines(out, theirs)
+	endLine(out)
+	writeMarker(out, endMarker, l.Theirs)
+}
+
+func writeMarker(out *bytes.Buffer, marker, label string) {
+	out.WriteString(marker)
+	if label != "" {
+		out.WriteString(" " + label)
+	}
+	out.WriteByte('\n')
+}
+
+// endLine ends the last written line when it does not have a line break.
+func endLine(out *bytes.Buffer) {
+	if b := out.Bytes(); len(b) > 0 && b[len(b)-1] != '\n' {
+		out.WriteByte('\n')
+	}
+}
+
+// lines splits the content into lines keeping the line breaks.
+func lines(b []byte) []string {
+	var ls []string
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i == -1 {
+			ls = append(ls, string(b))
+			break
+		}
+		ls = append(ls, string(b[:i+1]))
+		b = b[i+1:]
+	}
+	return ls
+}
+
+// match finds the longest common subsequence of lines a and b. It returns for
+// every line of a the index of the matching line of b, or -1 when the line is
+// not in the subsequence.
+func match(a, b []string) []int {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+
+	// common prefix and suffix are matched without building the table
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		m[pre] = pre
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		m[len(a)-1-suf] = len(b) - 1 - suf
+		suf++
+	}
+	a, b = a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			m[pre+i] = pre + j
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
@@ -0,0 +1,126 @@
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/antklim/chef/internal/merge"
+	"github.com/stretchr/testify/assert"
+)
+
+var labels = merge.Labels{Ours: "current", Theirs: "template"}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		base      string
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			desc:     "keeps unchanged content",
+			base:     "a\nb\nc\n",
+			ours:     "a\nb\nc\n",
+			theirs:   "a\nb\nc\n",
+			expected: "a\nb\nc\n",
+		},
+		{
+			desc:     "takes their changes",
+			base:     "a\nb\nc\n",
+			ours:     "a\nb\nc\n",
+			theirs:   "a\nB\nc\nd\n",
+			expected: "a\nB\nc\nd\n",
+		},
+		{
+			desc:     "keeps our changes",
+			base:     "a\nb\nc\n",
+			ours:     "x\na\nc\n",
+			theirs:   "a\nb\nc\n",
+			expected: "x\na\nc\n",
+		},
+		{
+			desc:     "merges changes of different lines",
+			base:     "a\nb\nc\nd\ne\n",
+			ours:     "a\nB\nc\nd\ne\n",
+			theirs:   "a\nb\nc\nD\ne\nf\n",
+			expected: "a\nB\nc\nD\ne\nf\n",
+		},
+		{
+			desc:     "takes the same change once",
+			base:     "a\nb\nc\n",
+			ours:     "a\nB\nc\n",
+			theirs:   "a\nB\nc\n",
+			expected: "a\nB\nc\n",
+		},
+		{
+			desc:     "takes deletions",
+			base:     "a\nb\nc\nd\n",
+			ours:     "a\nc\nd\n",
+			theirs:   "a\nb\nc\n",
+			expected: "a\nc\n",
+		},
+		{
+			desc:      "marks conflicting changes",
+			base:      "a\nb\nc\n",
+			ours:      "a\nours\nc\n",
+			theirs:    "a\ntheirs\nc\n",
+			expected:  "a\n<<<<<<< current\nours\n=======\ntheirs\n>>>>>>> template\nc\n",
+			conflicts: 1,
+		},
+		{
+			desc:      "marks conflicting changes of the last line without line break",
+			base:      "a\nb",
+			ours:      "a\nours",
+			theirs:    "a\ntheirs",
+			expected:  "a\n<<<<<<< current\nours\n=======\ntheirs\n>>>>>>> template\n",
+			conflicts: 1,
+		},
+		{
+			desc:      "counts every conflict",
+			base:      "a\nb\nc\nd\ne\n",
+			ours:      "a\nb1\nc\nd1\ne\n",
+			theirs:    "a\nb2\nc\nd2\ne\n",
+			expected:  "a\n<<<<<<< current\nb1\n=======\nb2\n>>>>>>> template\nc\n<<<<<<< current\nd1\n=======\nd2\n>>>>>>> template\ne\n",
+			conflicts: 2,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			merged, conflicts := merge.Merge([]byte(tC.base), []byte(tC.ours), []byte(tC.theirs), labels)
+			assert.Equal(t, tC.expected, string(merged))
+			assert.Equal(t, tC.conflicts, conflicts)
+		})
+	}
+}
+
+func TestMergeWithoutBase(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			desc:     "keeps equal content",
+			ours:     "a\nb\n",
+			theirs:   "a\nb\n",
+			expected: "a\nb\n",
+		},
+		{
+			desc:      "marks every difference",
+			ours:      "a\nb\nc\n",
+			theirs:    "a\nc\nd\n",
+			expected:  "a\n<<<<<<< current\nb\n=======\n>>>>>>> template\nc\n<<<<<<< current\n=======\nd\n>>>>>>> template\n",
+			conflicts: 2,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			merged, conflicts := merge.MergeWithoutBase([]byte(tC.ours), []byte(tC.theirs), labels)
+			assert.Equal(t, tC.expected, string(merged))
+			assert.Equal(t, tC.conflicts, conflicts)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+)
+
+// baseline reads the project baseline. The baseline of a project without the
+// baseline file is empty.
+func (p *Project) baseline() (chef.Baseline, error) {
+	data, err := p.opts.out.FileContent(path.Join(p.loc, chef.DefaultBaselineFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(chef.Baseline), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chef.ReadBaseline(bytes.NewReader(data))
+}
+
+// writeBaseline stores the baseline to .chef.baseline.yml file in the project
+// located at loc.
+func writeBaseline(out fsys.FS, loc string, b chef.Baseline) error {
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		return err
+	}
+
+	file := path.Join(loc, chef.DefaultBaselineFileName)
+	return out.WriteFile(file, buf.Bytes(), notationPerm)
+}
+
+// recordBaseline records the content of the files of the project located at
+// loc in the baseline. Files locations are relative to the project.
+func recordBaseline(b chef.Baseline, out fsys.FS, loc string, files []string) error {
+	for _, f := range files {
+		data, err := out.FileContent(path.Join(loc, f))
+		if err != nil {
+			return err
+		}
+		b[f] = string(data)
+	}
+	return nil
+}
+
+// updateBaseline removes the removed files from the project baseline and
+// records the content of the written files. Files locations are relative to
+// the project.
+func (p *Project) updateBaseline(written, removed []string) error {
+	b, err := p.baseline()
+	if err != nil {
+		return err
+	}
+	for _, f := range removed {
+		delete(b, f)
+	}
+	if err := recordBaseline(b, p.opts.out, p.loc, written); err != nil {
+		return err
+	}
+	return writeBaseline(p.opts.out, p.loc, b)
+}
+
+// filePaths returns locations of the project layout files relative to the
+// project.
+func (p *Project) filePaths() []string {
+	var files []string
+	for _, loc := range p.Paths() {
+		if _, ok := p.lout.FindNode(loc).(*node.Fnode); ok {
+			files = append(files, loc)
+		}
+	}
+	return files
+}
@@ -0,0 +1,78 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBaseline(t *testing.T, loc string) chef.Baseline {
+	t.Helper()
+
+	f, err := os.Open(path.Join(loc, chef.DefaultBaselineFileName))
+	require.NoError(t, err)
+	defer f.Close()
+	b, err := chef.ReadBaseline(f)
+	require.NoError(t, err)
+	return b
+}
+
+func TestProjectBaseline(t *testing.T) {
+	t.Run("records generated files", func(t *testing.T) {
+		_, loc := employedProject(t)
+
+		b := readBaseline(t, loc)
+		assert.Len(t, b, 6)
+		assert.NotContains(t, b, "handler")
+		for _, f := range []string{"go.mod", "main.go", "handler/http/router.go", "handler/http/echo.go"} {
+			data, err := os.ReadFile(path.Join(loc, f))
+			require.NoError(t, err)
+			assert.Equal(t, string(data), b[f], f)
+		}
+	})
+
+	t.Run("renames files", func(t *testing.T) {
+		p, loc := employedProject(t)
+		_, err := p.RenameComponent("http_handler", "echo", "ping", false)
+		require.NoError(t, err)
+
+		b := readBaseline(t, loc)
+		assert.NotContains(t, b, "handler/http/echo.go")
+		data, err := os.ReadFile(path.Join(loc, "handler/http/ping.go"))
+		require.NoError(t, err)
+		assert.Equal(t, string(data), b["handler/http/ping.go"])
+	})
+
+	t.Run("keeps baseline of moved modified files", func(t *testing.T) {
+		p, loc := employedProject(t)
+		generated := readBaseline(t, loc)["handler/http/echo.go"]
+		require.NoError(t, os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644))
+
+		_, err := p.RenameComponent("http_handler", "echo", "ping", true)
+		require.NoError(t, err)
+		assert.Equal(t, generated, readBaseline(t, loc)["handler/http/ping.go"])
+	})
+
+	t.Run("removes unemployed files", func(t *testing.T) {
+		p, loc := employedProject(t)
+		_, err := p.UnemployComponent("http_handler", "echo", false)
+		require.NoError(t, err)
+		assert.NotContains(t, readBaseline(t, loc), "handler/http/echo.go")
+	})
+
+	t.Run("records synced files", func(t *testing.T) {
+		p, loc := employedProject(t)
+		require.NoError(t, os.Remove(path.Join(loc, chef.DefaultBaselineFileName)))
+		require.NoError(t, os.Remove(path.Join(loc, "main.go")))
+
+		_, err := p.Sync(false)
+		require.NoError(t, err)
+		b := readBaseline(t, loc)
+		assert.Len(t, b, 1)
+		assert.Contains(t, b, "main.go")
+	})
+}
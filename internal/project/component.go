@@ -1,14 +1,22 @@
 package project
 
 import (
+	"fmt"
 	"path"
+	"strings"
 	"text/template"
 
+	"github.com/antklim/chef/internal/layout"
 	templ "github.com/antklim/chef/internal/project/template"
+	"github.com/pkg/errors"
 )
 
 const (
 	httpHandler = "http_handler"
+	grpcService = "grpc_service"
+	pkgType     = "type"
+	pkgExample  = "example"
+	cliCommand  = "command"
 )
 
 type Component struct {
@@ -16,6 +24,36 @@ type Component struct {
 	Loc  string
 	Desc string
 	Tmpl *template.Template
+	Ext  string // extension of created files, by default ".go", can be compound like "_test.go" or ".up.sql"
+	Lang string // language of created files, by default derived from the extension
+
+	// TmplLoc is the template file location of the custom component relative
+	// to the project. Custom components are stored in the project notation.
+	TmplLoc string
+
+	// Outputs are the additional files created by the component together
+	// with the main file.
+	Outputs []Output
+
+	// Params are the typed inputs of the component templates.
+	Params []Param
+
+	// Naming is the rule names of the component elements follow, by default
+	// names are Go identifiers.
+	Naming Naming
+}
+
+// Output is an additional file created by the component.
+type Output struct {
+	Loc string // location of the file in the project layout
+
+	// Pattern is the file name template, it is executed with the component
+	// data, for example "{{.Name}}_test.go".
+	Pattern string
+
+	Tmpl    *template.Template
+	TmplLoc string // template file location relative to the project, set for custom components
+	Lang    string // language of the file, by default derived from the file extension
 }
 
 func NewComponent(name, loc, desc string, tmpl *template.Template) Component {
@@ -27,6 +65,58 @@ func NewComponent(name, loc, desc string, tmpl *template.Template) Component {
 	}
 }
 
+// ext returns extension of files created by the component.
+func (c Component) ext() string {
+	if c.Ext == "" {
+		return defaultExt
+	}
+	return c.Ext
+}
+
+// elementName returns the component node name and the component element name
+// used in templates. The name can be provided with or without the component
+// file extension, periods are allowed only in the extension.
+func (c Component) elementName(name string) (string, string, error) {
+	ext := c.ext()
+	tname, ok := strings.CutSuffix(name, ext)
+	switch {
+	case !strings.Contains(tname, "."):
+		return tname + ext, tname, nil
+	case !ok && strings.Count(name, ".") == 1:
+		return "", "", fmt.Errorf("unknown file extension %q", path.Ext(name))
+	}
+	return "", "", errInvalidNodeName
+}
+
+// validateExt checks that the component extension can be appended to the
+// element names.
+func (c Component) validateExt() error {
+	ext := c.ext()
+	if !strings.Contains(ext, ".") || strings.HasSuffix(ext, ".") || strings.ContainsAny(ext, `/\ `) {
+		return fmt.Errorf("invalid extension %q", ext)
+	}
+	return nil
+}
+
+// filename returns the output file name executing the pattern with the data.
+func (o Output) filename(data interface{}) (string, error) {
+	t, err := template.New("").Funcs(templ.Funcs()).Parse(o.Pattern)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid output pattern %q", o.Pattern)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", errors.Wrapf(err, "invalid output pattern %q", o.Pattern)
+	}
+
+	name := b.String()
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return "", fmt.Errorf("output pattern %q produced invalid file name %q", o.Pattern, name)
+	}
+	return name, nil
+}
+
 type componentsMaker interface {
 	makeComponents() map[string]Component
 }
@@ -35,6 +125,15 @@ func componentsFactory(category, server string) componentsMaker {
 	if category == categoryService && server == serverHTTP {
 		return httpServiceComponets{}
 	}
+	if category == categoryService && server == serverGRPC {
+		return grpcServiceComponents{}
+	}
+	if category == categoryPackage && server == serverNone {
+		return pkgComponents{}
+	}
+	if category == categoryCLI && server == serverNone {
+		return cliComponents{}
+	}
 	return nil
 }
 
@@ -43,10 +142,86 @@ type httpServiceComponets struct{}
 func (httpServiceComponets) makeComponents() map[string]Component {
 	c := make(map[string]Component)
 	c[httpHandler] = Component{
-		Name: httpHandler,
-		Loc:  path.Join(dirHandler, dirHTTP),
-		Desc: "HTTP handler",
-		Tmpl: templ.Get(templ.HTTPEndpoint),
+		Name:   httpHandler,
+		Loc:    path.Join(dirHandler, dirHTTP),
+		Desc:   "HTTP handler",
+		Tmpl:   templ.Get(templ.HTTPEndpoint),
+		Naming: NamingKebab,
+		Params: []Param{
+			{
+				Name:    "method",
+				Type:    ParamEnum,
+				Default: "GET",
+				Values:  []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+				Desc:    "HTTP method of the endpoint",
+			},
+			{
+				Name: pathParam,
+				Type: ParamString,
+				Desc: "route of the endpoint, by default /<name>",
+			},
+		},
+		Outputs: []Output{
+			{
+				Loc:     path.Join(dirHandler, dirHTTP),
+				Pattern: "{{.Name}}_test.go",
+				Tmpl:    templ.Get(templ.HTTPEndpointTest),
+			},
+		},
+	}
+	return c
+}
+
+type grpcServiceComponents struct{}
+
+func (grpcServiceComponents) makeComponents() map[string]Component {
+	c := make(map[string]Component)
+	c[grpcService] = Component{
+		Name: grpcService,
+		Loc:  path.Join(dirHandler, dirGRPC),
+		Desc: "gRPC service",
+		Tmpl: templ.Get(templ.GRPCHandler),
+		Outputs: []Output{
+			{
+				Loc:     dirProto,
+				Pattern: "{{.Name}}.proto",
+				Tmpl:    templ.Get(templ.GRPCProto),
+			},
+		},
+	}
+	return c
+}
+
+type pkgComponents struct{}
+
+func (pkgComponents) makeComponents() map[string]Component {
+	c := make(map[string]Component)
+	c[pkgType] = Component{
+		Name: pkgType,
+		Loc:  layout.Root,
+		Desc: "Package type",
+		Tmpl: templ.Get(templ.PkgType),
+	}
+	c[pkgExample] = Component{
+		Name: pkgExample,
+		Loc:  layout.Root,
+		Desc: "Package example",
+		Tmpl: templ.Get(templ.PkgExampleFunc),
+		Ext:  "_test.go",
+	}
+	return c
+}
+
+type cliComponents struct{}
+
+func (cliComponents) makeComponents() map[string]Component {
+	c := make(map[string]Component)
+	c[cliCommand] = Component{
+		Name:   cliCommand,
+		Loc:    path.Join(dirInternal, dirCLI),
+		Desc:   "CLI subcommand",
+		Tmpl:   templ.Get(templ.CLICommand),
+		Naming: NamingKebab,
 	}
 	return c
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestComponentsFactory(t *testing.T) {
@@ -46,3 +47,90 @@ func TestHTTPServiceComponentsFactory(t *testing.T) {
 	}
 	assert.Len(t, c, len(expectedComponents))
 }
+
+func TestGRPCServiceComponentsFactory(t *testing.T) {
+	f := componentsFactory(category("srv"), server("grpc"))
+	assert.NotNil(t, f)
+	c := f.makeComponents()
+	assert.NotNil(t, c)
+
+	assert.Len(t, c, 1)
+	assert.Contains(t, c, "grpc_service")
+	assert.Equal(t, "handler/grpc", c["grpc_service"].Loc)
+	assert.Len(t, c["grpc_service"].Outputs, 1)
+	assert.Equal(t, "proto", c["grpc_service"].Outputs[0].Loc)
+	assert.Equal(t, "{{.Name}}.proto", c["grpc_service"].Outputs[0].Pattern)
+}
+
+func TestPackageComponentsFactory(t *testing.T) {
+	f := componentsFactory(category("package"), server(""))
+	assert.NotNil(t, f)
+	c := f.makeComponents()
+	assert.NotNil(t, c)
+
+	expectedComponents := []string{"type", "example"}
+	for _, v := range expectedComponents {
+		assert.Contains(t, c, v)
+	}
+	assert.Len(t, c, len(expectedComponents))
+	assert.Equal(t, "_test.go", c["example"].Ext)
+}
+
+func TestCLIComponentsFactory(t *testing.T) {
+	f := componentsFactory(category("cli"), server(""))
+	assert.NotNil(t, f)
+	c := f.makeComponents()
+	assert.NotNil(t, c)
+
+	assert.Len(t, c, 1)
+	assert.Contains(t, c, "command")
+	assert.Equal(t, "internal/cli", c["command"].Loc)
+}
+
+func TestOutputFilename(t *testing.T) {
+	data := struct{ Name string }{Name: "UserProfile"}
+
+	t.Run("executes pattern with functions library", func(t *testing.T) {
+		o := Output{Pattern: "{{ .Name | snake | plural }}_test.go"}
+		name, err := o.filename(data)
+		require.NoError(t, err)
+		assert.Equal(t, "user_profiles_test.go", name)
+	})
+
+	t.Run("fails when pattern produces invalid file name", func(t *testing.T) {
+		o := Output{Pattern: "{{ .Name | kebab | replace \"-\" \"/\" }}.go"}
+		_, err := o.filename(data)
+		assert.EqualError(t, err, `output pattern "{{ .Name | kebab | replace \"-\" \"/\" }}.go" produced invalid file name "user/profile.go"`)
+	})
+}
+
+func TestComponentElementName(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		ext   string
+		name  string
+		nname string
+		tname string
+		err   string
+	}{
+		{desc: "adds default extension", name: "echo", nname: "echo.go", tname: "echo"},
+		{desc: "trims default extension", name: "echo.go", nname: "echo.go", tname: "echo"},
+		{desc: "adds compound extension", ext: "_test.go", name: "echo", nname: "echo_test.go", tname: "echo"},
+		{desc: "trims compound extension", ext: ".up.sql", name: "users.up.sql", nname: "users.up.sql", tname: "users"},
+		{desc: "fails on unknown extension", ext: ".up.sql", name: "users.sql", err: `unknown file extension ".sql"`},
+		{desc: "fails on periods in name", ext: ".up.sql", name: "create.users.up.sql", err: "periods not allowed in a file name"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := Component{Name: "test", Ext: tC.ext}
+			nname, tname, err := c.elementName(tC.name)
+			if tC.err != "" {
+				assert.EqualError(t, err, tC.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.nname, nname)
+			assert.Equal(t, tC.tname, tname)
+		})
+	}
+}
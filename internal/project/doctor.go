@@ -0,0 +1,95 @@
+package project
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Finding describes a mismatch between the project on disk and its layout.
+type Finding struct {
+	Path    string // location relative to the project
+	Problem string
+}
+
+// Doctor checks the project on disk against its layout. It reports missing
+// nodes, unexpected entries in the layout directories, permission mismatches
+// and files which content differs from a fresh render.
+func (p *Project) Doctor() ([]Finding, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	nodes, err := p.Inspect()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.diagnose(".", nodes)
+}
+
+func (p *Project) diagnose(loc string, nodes []NodeInfo) ([]Finding, error) {
+	findings, err := p.unexpected(loc, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range nodes {
+		if n.State == NodeMissing {
+			problem := "missing file"
+			if n.Dir {
+				problem = "missing directory"
+			}
+			// nodes of the missing directory are not reported
+			findings = append(findings, Finding{Path: n.Path, Problem: problem})
+			continue
+		}
+
+		for _, d := range n.Drift {
+			findings = append(findings, Finding{Path: n.Path, Problem: d})
+		}
+
+		if n.Dir && !slices.Contains(n.Drift, driftNotDir) {
+			subfindings, err := p.diagnose(n.Path, n.Nodes)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, subfindings...)
+		}
+	}
+
+	return findings, nil
+}
+
+// unexpected returns findings for the entries of the directory that are not
+// defined by the layout nodes. Hidden entries, such as the notation file or VCS
+// directories, and go.sum next to the go.mod node are expected.
+func (p *Project) unexpected(loc string, nodes []NodeInfo) ([]Finding, error) {
+	entries, err := p.opts.out.DirEntries(path.Join(p.loc, loc))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read directory %q", loc)
+	}
+
+	names := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		names[n.Name()] = true
+	}
+
+	var findings []Finding
+	for _, e := range entries {
+		name := e.Name()
+		if names[name] || strings.HasPrefix(name, ".") || (name == "go.sum" && names["go.mod"]) {
+			continue
+		}
+
+		problem := "unexpected file"
+		if e.IsDir() {
+			problem = "unexpected directory"
+		}
+		findings = append(findings, Finding{Path: path.Join(loc, name), Problem: problem})
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,71 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectDoctor(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.Doctor()
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("fails when project does not exist", func(t *testing.T) {
+		p := project.New("project", project.WithRoot(t.TempDir()), project.WithServer("http"))
+		err := p.Init()
+		require.NoError(t, err)
+
+		_, err = p.Doctor()
+		assert.ErrorContains(t, err, `failed to read directory ".":`)
+	})
+
+	t.Run("reports project problems", func(t *testing.T) {
+		p := project.New("project",
+			project.WithRoot(t.TempDir()),
+			project.WithServer("http"),
+			project.WithModule("project.git"))
+		err := p.Init()
+		require.NoError(t, err)
+
+		loc, err := p.Build()
+		require.NoError(t, err)
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+
+		err = os.RemoveAll(path.Join(loc, "test"))
+		require.NoError(t, err)
+		err = os.Chmod(path.Join(loc, "app"), 0700)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(loc, "handler/notes.txt"), nil, 0644)
+		require.NoError(t, err)
+		err = os.Mkdir(path.Join(loc, "tmp"), 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(loc, "go.sum"), nil, 0644)
+		require.NoError(t, err)
+
+		findings, err = p.Doctor()
+		require.NoError(t, err)
+
+		expected := []project.Finding{
+			{Path: "tmp", Problem: "unexpected directory"},
+			{Path: "app", Problem: "permissions 0700, expected 0755"},
+			{Path: "handler/notes.txt", Problem: "unexpected file"},
+			{Path: "handler/http/echo.go", Problem: "content differs"},
+			{Path: "test", Problem: "missing directory"},
+		}
+		assert.Equal(t, expected, findings)
+	})
+}
@@ -0,0 +1,170 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+)
+
+// NodeState describes the state of a layout node in the file system.
+type NodeState int
+
+const (
+	// NodeExists node exists in the file system as defined by the layout.
+	NodeExists NodeState = iota
+	// NodeDrifted node exists in the file system, but it differs from
+	// the layout: type, permissions or content do not match.
+	NodeDrifted
+	// NodeMissing node does not exist in the file system.
+	NodeMissing
+)
+
+func (s NodeState) String() string {
+	switch s {
+	case NodeExists:
+		return "exists"
+	case NodeDrifted:
+		return "drifted"
+	case NodeMissing:
+		return "missing"
+	}
+	return "unknown"
+}
+
+// NodeInfo describes a layout node of the project and its state in the file
+// system.
+type NodeInfo struct {
+	Path      string // node location relative to the project
+	Dir       bool
+	Perm      fs.FileMode
+	Template  string // name of the file node template
+	Component string // component employed to create the node
+	State     NodeState
+	Drift     []string // differences between the node and the file system
+	Nodes     []NodeInfo
+}
+
+// Name returns node name.
+func (i NodeInfo) Name() string {
+	return path.Base(i.Path)
+}
+
+// Inspect returns the project layout nodes with their state in the file
+// system. File nodes are rendered to compare their content with the files.
+func (p *Project) Inspect() ([]NodeInfo, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+	return p.inspectNodes(p.lout.Nodes(), "")
+}
+
+func (p *Project) inspectNodes(nodes []node.Node, loc string) ([]NodeInfo, error) {
+	infos := make([]NodeInfo, 0, len(nodes))
+	for _, n := range nodes {
+		info, err := p.inspectNode(n, path.Join(loc, n.Name()))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (p *Project) inspectNode(n node.Node, loc string) (NodeInfo, error) {
+	info := NodeInfo{Path: loc}
+	if en, ok := p.enodes[loc]; ok {
+		info.Component = en.component
+	}
+
+	fi, err := p.opts.out.Stat(path.Join(p.loc, loc))
+	if err != nil {
+		info.State = NodeMissing
+	}
+
+	switch n := n.(type) {
+	case *node.Dnode:
+		info.Dir = true
+		info.Perm = n.Perm()
+		if fi != nil {
+			info.Drift = dirDrift(fi, n.Perm())
+		}
+		subnodes, err := p.inspectNodes(n.Nodes(), loc)
+		if err != nil {
+			return NodeInfo{}, err
+		}
+		info.Nodes = subnodes
+	case *node.Fnode:
+		info.Perm = n.Perm()
+		if t := n.Template(); t != nil {
+			info.Template = t.Name()
+		}
+		if fi != nil {
+			drift, err := p.fileDrift(n, loc, fi)
+			if err != nil {
+				return NodeInfo{}, errors.Wrapf(err, "failed to inspect node %q", loc)
+			}
+			info.Drift = drift
+		}
+	}
+
+	if len(info.Drift) > 0 {
+		info.State = NodeDrifted
+	}
+	return info, nil
+}
+
+const (
+	driftNotDir  = "not a directory"
+	driftNotFile = "not a file"
+	driftContent = "content differs"
+)
+
+func dirDrift(fi fs.FileInfo, perm fs.FileMode) []string {
+	if !fi.IsDir() {
+		return []string{driftNotDir}
+	}
+	if fi.Mode().Perm() != perm {
+		return []string{permDrift(fi.Mode().Perm(), perm)}
+	}
+	return nil
+}
+
+func (p *Project) fileDrift(n *node.Fnode, loc string, fi fs.FileInfo) ([]string, error) {
+	if fi.IsDir() {
+		return []string{driftNotFile}, nil
+	}
+
+	var drift []string
+	if fi.Mode().Perm() != n.Perm() {
+		drift = append(drift, permDrift(fi.Mode().Perm(), n.Perm()))
+	}
+
+	expected, err := n.Render(p.nodeData(loc))
+	if err != nil {
+		return nil, err
+	}
+	actual, err := p.opts.out.FileContent(path.Join(p.loc, loc))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(expected, actual) {
+		drift = append(drift, driftContent)
+	}
+	return drift, nil
+}
+
+// nodeData returns the data the node at the location is built with.
+func (p *Project) nodeData(loc string) interface{} {
+	if en, ok := p.enodes[loc]; ok {
+		return en.data
+	}
+	return p.projectData()
+}
+
+func permDrift(actual, expected fs.FileMode) string {
+	return fmt.Sprintf("permissions %04o, expected %04o", uint32(actual), uint32(expected))
+}
@@ -0,0 +1,84 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectInspect(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.Inspect()
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("reports nodes state", func(t *testing.T) {
+		p := project.New("project",
+			project.WithRoot(t.TempDir()),
+			project.WithServer("http"),
+			project.WithModule("project.git"))
+		err := p.Init()
+		require.NoError(t, err)
+
+		nodes, err := p.Inspect()
+		require.NoError(t, err)
+		for _, n := range nodes {
+			assert.Equal(t, project.NodeMissing, n.State, n.Path)
+		}
+
+		loc, err := p.Build()
+		require.NoError(t, err)
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+
+		err = os.Chmod(path.Join(loc, "main.go"), 0600)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(loc, "go.mod"), []byte("module foo\n"), 0644)
+		require.NoError(t, err)
+		err = os.RemoveAll(path.Join(loc, "test"))
+		require.NoError(t, err)
+
+		nodes, err = p.Inspect()
+		require.NoError(t, err)
+
+		infos := flattenNodes(nodes)
+
+		testCases := []struct {
+			path      string
+			state     project.NodeState
+			component string
+			drift     []string
+		}{
+			{path: "handler", state: project.NodeExists},
+			{path: "handler/http/echo.go", state: project.NodeExists, component: "http_handler"},
+			{path: "handler/http/router.go", state: project.NodeExists},
+			{path: "test", state: project.NodeMissing},
+			{path: "main.go", state: project.NodeDrifted, drift: []string{"permissions 0600, expected 0644"}},
+			{path: "go.mod", state: project.NodeDrifted, drift: []string{"content differs"}},
+		}
+
+		for _, tC := range testCases {
+			info, ok := infos[tC.path]
+			require.True(t, ok, tC.path)
+			assert.Equal(t, tC.state, info.State, tC.path)
+			assert.Equal(t, tC.component, info.Component, tC.path)
+			assert.Equal(t, tC.drift, info.Drift, tC.path)
+		}
+	})
+}
+
+func flattenNodes(nodes []project.NodeInfo) map[string]project.NodeInfo {
+	infos := make(map[string]project.NodeInfo)
+	for _, n := range nodes {
+		infos[n.Path] = n
+		for k, v := range flattenNodes(n.Nodes) {
+			infos[k] = v
+		}
+	}
+	return infos
+}
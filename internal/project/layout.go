@@ -1,6 +1,8 @@
 package project
 
 import (
+	"path"
+
 	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/antklim/chef/internal/project/template"
@@ -9,24 +11,39 @@ import (
 const (
 	dirAdapter  = "adapter"
 	dirApp      = "app"
+	dirCLI      = "cli"
+	dirCmd      = "cmd"
+	dirGRPC     = "grpc"
 	dirHandler  = "handler"
 	dirHTTP     = "http"
+	dirInternal = "internal"
 	dirServer   = "server"
+	dirProto    = "proto"
 	dirProvider = "provider"
 	dirTest     = "test"
+	dirTestdata = "testdata"
 )
 
 type layoutMaker interface {
 	makeLayout() *layout.Layout
 }
 
-func layoutFactory(category, server string) layoutMaker {
+func layoutFactory(category, server, name string) layoutMaker {
 	if category == categoryService && server == serverHTTP {
 		return httpServiceLayout{}
 	}
+	if category == categoryService && server == serverGRPC {
+		return grpcServiceLayout{}
+	}
 	if category == categoryService && server == serverNone {
 		return serviceLayout{}
 	}
+	if category == categoryPackage && server == serverNone {
+		return pkgLayout{pkg: template.Pkg(path.Base(name))}
+	}
+	if category == categoryCLI && server == serverNone {
+		return cliLayout{cmd: name}
+	}
 	return nil
 }
 
@@ -64,3 +81,57 @@ func (httpServiceLayout) makeLayout() *layout.Layout {
 	}
 	return layout.New(nodes...)
 }
+
+type grpcServiceLayout struct{}
+
+func (grpcServiceLayout) makeLayout() *layout.Layout {
+	grpcRegistry := node.NewFnode("registry.go", node.WithTemplate(template.Get(template.GRPCRegistry)))
+	grpcHandlerNode := node.NewDnode(dirGRPC, node.WithSubNodes(grpcRegistry))
+	grpcServer := node.NewFnode("server.go", node.WithTemplate(template.Get(template.GRPCServer)))
+	grpcServerNode := node.NewDnode(dirGRPC, node.WithSubNodes(grpcServer))
+	grpcSrvMain := node.NewFnode("main.go", node.WithTemplate(template.Get(template.GRPCService)))
+
+	nodes := []node.Node{
+		node.NewDnode(dirAdapter),
+		node.NewDnode(dirApp),
+		node.NewDnode(dirHandler, node.WithSubNodes(grpcHandlerNode)),
+		node.NewDnode(dirProto),
+		node.NewDnode(dirProvider),
+		node.NewDnode(dirServer, node.WithSubNodes(grpcServerNode)),
+		node.NewDnode(dirTest),
+		grpcSrvMain,
+	}
+	return layout.New(nodes...)
+}
+
+type pkgLayout struct {
+	pkg string // package name
+}
+
+func (l pkgLayout) makeLayout() *layout.Layout {
+	nodes := []node.Node{
+		node.NewDnode(dirInternal),
+		node.NewDnode(dirTestdata),
+		node.NewFnode(l.pkg+".go", node.WithTemplate(template.Get(template.PkgPackage))),
+		node.NewFnode("doc.go", node.WithTemplate(template.Get(template.PkgDoc))),
+		node.NewFnode("example_test.go", node.WithTemplate(template.Get(template.PkgExample))),
+	}
+	return layout.New(nodes...)
+}
+
+type cliLayout struct {
+	cmd string // command name
+}
+
+func (l cliLayout) makeLayout() *layout.Layout {
+	cliMain := node.NewFnode("main.go", node.WithTemplate(template.Get(template.CLIMain)))
+	cliRoot := node.NewFnode("root.go", node.WithTemplate(template.Get(template.CLIRoot)))
+	cliFlag := node.NewFnode("flag.go", node.WithTemplate(template.Get(template.CLIFlag)))
+	nodes := []node.Node{
+		node.NewDnode(dirCmd, node.WithSubNodes(
+			node.NewDnode(l.cmd, node.WithSubNodes(cliMain)))),
+		node.NewDnode(dirInternal, node.WithSubNodes(
+			node.NewDnode(dirCLI, node.WithSubNodes(cliRoot, cliFlag)))),
+	}
+	return layout.New(nodes...)
+}
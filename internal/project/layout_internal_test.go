@@ -21,17 +21,22 @@ func TestLayoutFactory(t *testing.T) {
 			category: "srv",
 			serever:  "bar",
 		},
+		{
+			desc:     "returns nil for package category and http server",
+			category: "pkg",
+			serever:  "http",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			lf := layoutFactory(category(tC.category), server(tC.serever))
+			lf := layoutFactory(category(tC.category), server(tC.serever), "test")
 			assert.Nil(t, lf)
 		})
 	}
 }
 
 func TestServiceLayoutFactory(t *testing.T) {
-	f := layoutFactory(category("srv"), server(""))
+	f := layoutFactory(category("srv"), server(""), "test")
 	assert.NotNil(t, f)
 	l := f.makeLayout()
 	assert.NotNil(t, l)
@@ -44,7 +49,7 @@ func TestServiceLayoutFactory(t *testing.T) {
 }
 
 func TestHTTPServiceLayoutFactory(t *testing.T) {
-	f := layoutFactory(category("service"), server("http"))
+	f := layoutFactory(category("service"), server("http"), "test")
 	assert.NotNil(t, f)
 	l := f.makeLayout()
 	assert.NotNil(t, l)
@@ -55,3 +60,43 @@ func TestHTTPServiceLayoutFactory(t *testing.T) {
 		assert.NotNil(t, node)
 	}
 }
+
+func TestGRPCServiceLayoutFactory(t *testing.T) {
+	f := layoutFactory(category("srv"), server("grpc"), "test")
+	assert.NotNil(t, f)
+	l := f.makeLayout()
+	assert.NotNil(t, l)
+
+	expectedNodes := []string{"adapter", "app", "handler/grpc/registry.go", "proto", "provider",
+		"server/grpc/server.go", "test", "main.go"}
+	for _, n := range expectedNodes {
+		node := l.FindNode(n)
+		assert.NotNil(t, node, n)
+	}
+}
+
+func TestPackageLayoutFactory(t *testing.T) {
+	f := layoutFactory(category("pkg"), server(""), "cheftest")
+	assert.NotNil(t, f)
+	l := f.makeLayout()
+	assert.NotNil(t, l)
+
+	expectedNodes := []string{"internal", "testdata", "cheftest.go", "doc.go", "example_test.go"}
+	for _, n := range expectedNodes {
+		node := l.FindNode(n)
+		assert.NotNil(t, node, n)
+	}
+}
+
+func TestCLILayoutFactory(t *testing.T) {
+	f := layoutFactory(category("cli"), server(""), "cheftool")
+	assert.NotNil(t, f)
+	l := f.makeLayout()
+	assert.NotNil(t, l)
+
+	expectedNodes := []string{"cmd/cheftool/main.go", "internal/cli/root.go", "internal/cli/flag.go"}
+	for _, n := range expectedNodes {
+		node := l.FindNode(n)
+		assert.NotNil(t, node, n)
+	}
+}
@@ -0,0 +1,130 @@
+package project
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	templ "github.com/antklim/chef/internal/project/template"
+)
+
+// Naming is a rule names of the component elements follow.
+type Naming string
+
+// Supported component naming rules.
+const (
+	NamingIdent Naming = "ident" // Go identifier, the default rule
+	NamingKebab Naming = "kebab" // Go identifier words joined with hyphens
+	NamingFile  Naming = "file"  // safe file name
+)
+
+// validate checks that the naming rule is known.
+func (n Naming) validate() error {
+	switch n {
+	case "", NamingIdent, NamingKebab, NamingFile:
+		return nil
+	}
+	return fmt.Errorf("unknown naming %q", n)
+}
+
+// check checks the element name against the naming rule. It returns the
+// reason the name does not follow the rule, or an empty string.
+func (n Naming) check(name string) string {
+	if n == NamingFile {
+		return ""
+	}
+
+	for i, r := range name {
+		switch {
+		case i == 0 && !unicode.IsLetter(r):
+			return "must start with a letter"
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		case r == '-' && n == NamingKebab:
+		default:
+			return fmt.Sprintf("contains invalid character %q", r)
+		}
+	}
+
+	// Kebab names are used in templates as identifiers in camel case.
+	if id := templ.Camel(name); token.IsKeyword(name) || (n == NamingKebab && token.IsKeyword(id)) {
+		return "is a Go keyword"
+	}
+	return ""
+}
+
+// suggest returns a name derived from the invalid name that follows the
+// naming rule, or an empty string.
+func (n Naming) suggest(name string) string {
+	var s string
+	switch n {
+	case NamingKebab, NamingFile:
+		s = strings.TrimLeft(templ.Kebab(name), "0123456789-")
+	default:
+		s = strings.TrimLeft(templ.Snake(name), "0123456789_")
+		if token.IsKeyword(s) {
+			s += "_"
+		}
+	}
+	if s == "" || s == name || checkFilename(s) != "" || n.check(s) != "" {
+		return ""
+	}
+	return s
+}
+
+// checkFilename checks that the name is safe to use as a file name in the
+// component location. It returns the reason the name is not safe, or an empty
+// string.
+func checkFilename(name string) string {
+	switch {
+	case name == "":
+		return "cannot be empty"
+	case strings.ContainsAny(name, `/\`):
+		return "must not contain path separators"
+	case strings.HasPrefix(name, "."):
+		return "must not start with a period"
+	case strings.HasPrefix(name, "-"):
+		return "must not start with a hyphen"
+	}
+
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			return "must not contain spaces"
+		case !unicode.IsPrint(r), strings.ContainsRune(`<>:"|?*`, r):
+			return fmt.Sprintf("contains invalid character %q", r)
+		}
+	}
+	return ""
+}
+
+// naming returns the naming rule of the component elements.
+func (c Component) naming() Naming {
+	if c.Naming == "" {
+		return NamingIdent
+	}
+	return c.Naming
+}
+
+// validateName checks that the name of a new component element is a safe file
+// name and the element name follows the component naming rule. The name can
+// be provided with or without the component file extension.
+func (c Component) validateName(name string) error {
+	reason := checkFilename(name)
+	if reason == "" {
+		_, tname, err := c.elementName(name)
+		if err != nil {
+			return err
+		}
+		reason = c.naming().check(tname)
+	}
+	if reason == "" {
+		return nil
+	}
+
+	err := fmt.Errorf("invalid name %q: %s", name, reason)
+	if s := c.naming().suggest(strings.TrimSuffix(name, c.ext())); s != "" {
+		err = fmt.Errorf("%v, try %q", err, s)
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentValidateName(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		naming Naming
+		name   string
+		err    string
+	}{
+		{
+			desc: "accepts Go identifier",
+			name: "user_profile",
+		},
+		{
+			desc: "accepts name with extension",
+			name: "userProfile.go",
+		},
+		{
+			desc: "rejects empty name",
+			name: "",
+			err:  `invalid name "": cannot be empty`,
+		},
+		{
+			desc: "rejects path traversal",
+			name: "../x",
+			err:  `invalid name "../x": must not contain path separators, try "x"`,
+		},
+		{
+			desc: "rejects hidden file",
+			name: ".env",
+			err:  `invalid name ".env": must not start with a period, try "env"`,
+		},
+		{
+			desc: "rejects spaces",
+			name: "user profile",
+			err:  `invalid name "user profile": must not contain spaces, try "user_profile"`,
+		},
+		{
+			desc: "rejects hyphens",
+			name: "user-profile.go",
+			err:  `invalid name "user-profile.go": contains invalid character '-', try "user_profile"`,
+		},
+		{
+			desc: "rejects leading digit",
+			name: "2fa",
+			err:  `invalid name "2fa": must start with a letter, try "fa"`,
+		},
+		{
+			desc: "rejects Go keyword",
+			name: "type",
+			err:  `invalid name "type": is a Go keyword, try "type_"`,
+		},
+		{
+			desc:   "accepts kebab name",
+			naming: NamingKebab,
+			name:   "user-profile",
+		},
+		{
+			desc:   "rejects kebab name starting with hyphen",
+			naming: NamingKebab,
+			name:   "-profile",
+			err:    `invalid name "-profile": must not start with a hyphen, try "profile"`,
+		},
+		{
+			desc:   "rejects kebab Go keyword",
+			naming: NamingKebab,
+			name:   "func",
+			err:    `invalid name "func": is a Go keyword`,
+		},
+		{
+			desc:   "rejects kebab name with spaces",
+			naming: NamingKebab,
+			name:   "user profile",
+			err:    `invalid name "user profile": must not contain spaces, try "user-profile"`,
+		},
+		{
+			desc:   "accepts any safe file name",
+			naming: NamingFile,
+			name:   "2fa+type",
+		},
+		{
+			desc:   "rejects unsafe file name",
+			naming: NamingFile,
+			name:   "a/b",
+			err:    `invalid name "a/b": must not contain path separators, try "a-b"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := Component{Name: "handler", Naming: tC.naming}
+			err := c.validateName(tC.name)
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestNamingValidate(t *testing.T) {
+	for _, n := range []Naming{"", NamingIdent, NamingKebab, NamingFile} {
+		assert.NoError(t, n.validate())
+	}
+	assert.EqualError(t, Naming("camel").validate(), `unknown naming "camel"`)
+}
@@ -0,0 +1,127 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParamType is a type of the component parameter.
+type ParamType string
+
+// Supported component parameter types.
+const (
+	ParamString ParamType = "string"
+	ParamBool   ParamType = "bool"
+	ParamEnum   ParamType = "enum"
+	ParamList   ParamType = "list" // comma separated values
+)
+
+// pathParam is the name of the parameter that overrides the component element
+// path when it is set.
+const pathParam = "path"
+
+// Param is a typed input of the component. Parameter values are available to
+// the component templates as .Params.<name>.
+type Param struct {
+	Name    string
+	Type    ParamType
+	Default string   // default value, it is used when value is not provided
+	Values  []string // allowed values of enum parameter
+	Desc    string
+}
+
+// validate checks that the parameter declaration is valid.
+func (p Param) validate() error {
+	if p.Name == "" {
+		return errors.New("parameter name cannot be empty")
+	}
+
+	switch p.Type {
+	case ParamString, ParamBool, ParamList:
+	case ParamEnum:
+		if len(p.Values) == 0 {
+			return fmt.Errorf("enum parameter %q does not have values", p.Name)
+		}
+	default:
+		return fmt.Errorf("parameter %q has unknown type %q", p.Name, p.Type)
+	}
+
+	if p.Default == "" {
+		return nil
+	}
+	if _, err := p.parse(p.Default); err != nil {
+		return errors.Wrap(err, "invalid default")
+	}
+	return nil
+}
+
+// parse returns the typed value of the parameter.
+func (p Param) parse(raw string) (interface{}, error) {
+	switch p.Type {
+	case ParamBool:
+		if raw == "" {
+			return false, nil
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of bool parameter %q", raw, p.Name)
+		}
+		return v, nil
+	case ParamEnum:
+		if raw == "" || !slices.Contains(p.Values, raw) {
+			return nil, fmt.Errorf("invalid value %q of parameter %q, expected one of %s",
+				raw, p.Name, strings.Join(p.Values, ", "))
+		}
+		return raw, nil
+	case ParamList:
+		v := []string{}
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				v = append(v, s)
+			}
+		}
+		return v, nil
+	}
+	return raw, nil
+}
+
+// paramValues validates the provided raw values of the component parameters.
+// It returns typed values of all parameters and their raw values, defaults
+// used for the values not provided.
+func paramValues(params []Param, values map[string]string) (map[string]interface{}, map[string]string, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.ContainsFunc(params, func(p Param) bool { return p.Name == name }) {
+			return nil, nil, fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+
+	if len(params) == 0 {
+		return map[string]interface{}{}, nil, nil
+	}
+
+	typed := make(map[string]interface{}, len(params))
+	raws := make(map[string]string, len(params))
+	for _, p := range params {
+		raw, ok := values[p.Name]
+		if !ok {
+			raw = p.Default
+		}
+		v, err := p.parse(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		typed[p.Name] = v
+		raws[p.Name] = raw
+	}
+	return typed, raws, nil
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamValidate(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		param Param
+		err   string
+	}{
+		{
+			desc:  "when name is empty",
+			param: Param{Type: ParamString},
+			err:   "parameter name cannot be empty",
+		},
+		{
+			desc:  "when type is unknown",
+			param: Param{Name: "size", Type: "int"},
+			err:   `parameter "size" has unknown type "int"`,
+		},
+		{
+			desc:  "when enum does not have values",
+			param: Param{Name: "method", Type: ParamEnum},
+			err:   `enum parameter "method" does not have values`,
+		},
+		{
+			desc:  "when default is invalid",
+			param: Param{Name: "auth", Type: ParamBool, Default: "maybe"},
+			err:   `invalid default: invalid value "maybe" of bool parameter "auth"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run("fails "+tC.desc, func(t *testing.T) {
+			err := tC.param.validate()
+			assert.EqualError(t, err, tC.err)
+		})
+	}
+}
+
+func TestParamValues(t *testing.T) {
+	params := []Param{
+		{Name: "method", Type: ParamEnum, Default: "GET", Values: []string{"GET", "POST"}},
+		{Name: "auth", Type: ParamBool},
+		{Name: "tags", Type: ParamList},
+		{Name: "path", Type: ParamString},
+	}
+
+	t.Run("fails when parameter is unknown", func(t *testing.T) {
+		_, _, err := paramValues(params, map[string]string{"verb": "GET"})
+		assert.EqualError(t, err, `unknown parameter "verb"`)
+	})
+
+	t.Run("fails when component does not have parameters", func(t *testing.T) {
+		_, _, err := paramValues(nil, map[string]string{"method": "GET"})
+		assert.EqualError(t, err, `unknown parameter "method"`)
+	})
+
+	t.Run("returns typed values with defaults", func(t *testing.T) {
+		typed, raws, err := paramValues(params, map[string]string{"auth": "true", "tags": "a, b,,c"})
+		require.NoError(t, err)
+
+		expected := map[string]interface{}{
+			"method": "GET",
+			"auth":   true,
+			"tags":   []string{"a", "b", "c"},
+			"path":   "",
+		}
+		assert.Equal(t, expected, typed)
+		assert.Equal(t, map[string]string{"method": "GET", "auth": "true", "tags": "a, b,,c", "path": ""}, raws)
+	})
+}
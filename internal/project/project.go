@@ -1,33 +1,55 @@
 package project
 
 import (
+	"bytes"
 	"fmt"
 	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/layout/node"
+	"github.com/antklim/chef/internal/project/template"
 	"github.com/pkg/errors"
 )
 
-// TODO (notation): read layout settings from yaml
-// TODO: init project with go.mod (when Go lang selected)
-// TODO: test/build generated go code
 // TODO: make layout and components pluggable
-// TODO: support functionality of bring your own templates
 
 const (
 	categoryUnknown = "unknown"
 	categoryService = "srv"
+	categoryPackage = "pkg"
+	categoryCLI     = "cli"
 )
 
 var categories = map[string]string{
 	"srv":     categoryService,
 	"service": categoryService,
+	"pkg":     categoryPackage,
+	"package": categoryPackage,
+	"cli":     categoryCLI,
+}
+
+// dependencies are the modules required by projects of a category.
+var dependencies = map[string][]template.Dependency{
+	categoryCLI: {
+		{Path: "github.com/spf13/cobra", Version: "v1.8.1"},
+	},
+}
+
+// serverDependencies are the modules required by service projects with
+// a server.
+var serverDependencies = map[string][]template.Dependency{
+	serverGRPC: {
+		{Path: "google.golang.org/grpc", Version: "v1.67.1"},
+		{Path: "google.golang.org/protobuf", Version: "v1.35.1"},
+	},
 }
 
 func category(v string) string {
@@ -43,11 +65,13 @@ const (
 	serverUnknown = "unknown"
 	serverNone    = ""
 	serverHTTP    = "http"
+	serverGRPC    = "grpc"
 )
 
 var servers = map[string]string{
 	"":     serverNone,
 	"http": serverHTTP,
+	"grpc": serverGRPC,
 }
 
 func server(v string) string {
@@ -59,30 +83,48 @@ func server(v string) string {
 	return srv
 }
 
+const goModFile = "go.mod"
+
 const (
-	defaultCategory = categoryService
-	defaultServer   = serverNone
-	defaultExt      = ".go" // default file extension
+	projectPerm  fs.FileMode = 0755
+	notationPerm fs.FileMode = 0644
+)
+
+const (
+	defaultCategory  = categoryService
+	defaultServer    = serverNone
+	defaultExt       = ".go" // default file extension
+	defaultGoVersion = "1.23"
 )
 
 var (
 	errEmptyProjectName     = errors.New("name cannot be empty")
 	errComponentTemplateNil = errors.New("nil component template")
+	errEmptyComponentName   = errors.New("component name cannot be empty")
+	errEmptyOutputPattern   = errors.New("component output pattern cannot be empty")
 	errNotInited            = errors.New("project not inited")
 	errInvalidNodeName      = errors.New("periods not allowed in a file name")
 )
 
 type projectOptions struct {
-	root string
-	cat  string
-	srv  string
-	mod  string
-	lout *layout.Layout
+	root  string
+	cat   string
+	srv   string
+	mod   string
+	gover string
+	tmpls string
+	comps []chef.Component
+	emps  []chef.Employed
+	ldef  *layout.Definition
+	lout  *layout.Layout
+	out   fsys.FS
 }
 
 var defaultProjectOptions = projectOptions{
-	cat: defaultCategory,
-	srv: defaultServer,
+	cat:   defaultCategory,
+	srv:   defaultServer,
+	gover: defaultGoVersion,
+	out:   fsys.OSFS{},
 }
 
 // Project stores the information to maintain components, layout and nodes.
@@ -92,7 +134,10 @@ type Project struct {
 	opts       projectOptions
 	loc        string
 	lout       *layout.Layout
+	ldef       layout.Definition // project layout definition without employed nodes
 	components map[string]Component
+	employed   []chef.Employed
+	enodes     map[string]employedNode // employed nodes by location in the project
 }
 
 // New project creates a new instance of a project.
@@ -108,6 +153,7 @@ func New(name string, opt ...Option) *Project {
 		name:       name,
 		opts:       opts,
 		components: make(map[string]Component),
+		enodes:     make(map[string]employedNode),
 	}
 	return p
 }
@@ -120,31 +166,79 @@ func (p *Project) Init() error {
 	if err := p.setLocation(); err != nil {
 		return errors.Wrap(err, "set location failed")
 	}
+	if err := p.setTemplates(); err != nil {
+		return errors.Wrap(err, "set templates failed")
+	}
 	if err := p.setLayout(); err != nil {
 		return errors.Wrap(err, "set layout failed")
 	}
+	if err := p.setGoMod(); err != nil {
+		return errors.Wrap(err, "set go.mod failed")
+	}
+	p.ldef = layout.Describe(p.lout, template.Get)
 	p.setComponents()
+	if err := p.setCustomComponents(); err != nil {
+		return errors.Wrap(err, "set components failed")
+	}
+	if err := p.setEmployed(); err != nil {
+		return errors.Wrap(err, "set employed components failed")
+	}
 	p.inited = true
 	return nil
 }
 
 // Build creates project layout and returns project location and any occurred
 // build error. In case of the error, the location is an empty string.
+//
+// The build is all-or-nothing. The project location is claimed by creating
+// its directory, which fails when anything exists at the location. The project
+// is built in a staging directory next to the location and moved to the
+// location only when all layout nodes and notation succeeded. Otherwise the
+// staging and the project directories are removed.
 func (p *Project) Build() (string, error) {
 	if !p.inited {
 		return "", errNotInited
 	}
-	if err := p.build(); err != nil {
+
+	out := p.opts.out
+	if err := p.claimLocation(out); err != nil {
 		return "", errors.Wrap(err, "build failed")
 	}
-	// writeNotation should be called after project layout created
-	// notation failed saved to the root directory of the project
-	if err := p.writeNotation(); err != nil {
-		return "", errors.Wrap(err, "project notation write failed")
+
+	staging := p.stagingLoc()
+	if err := p.buildAt(out, staging); err != nil {
+		// It's a clean up, thus ignore errors here.
+		_ = out.RemoveAll(staging)
+		_ = out.RemoveAll(p.loc)
+		return "", err
+	}
+
+	if err := p.moveStaged(out, staging); err != nil {
+		_ = out.RemoveAll(staging)
+		_ = out.RemoveAll(p.loc)
+		return "", errors.Wrap(err, "build failed")
 	}
 	return p.loc, nil
 }
 
+// Plan returns a list of entries the project build would create. It builds
+// the project in memory without touching the file system.
+func (p *Project) Plan() ([]fsys.Entry, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+	if err := p.checkLocation(p.opts.out); err != nil {
+		return nil, errors.Wrap(err, "plan failed")
+	}
+
+	mem := fsys.NewMemFS()
+	if err := p.buildAt(mem, p.loc); err != nil {
+		return nil, err
+	}
+
+	return mem.Entries(), nil
+}
+
 // RegisterComponent adds a component to the project.
 //
 // After component registered, new layout nodes can be added to project
@@ -157,13 +251,43 @@ func (p *Project) RegisterComponent(c Component) error {
 	if c.Tmpl == nil {
 		return errComponentTemplateNil
 	}
+	if err := p.checkComponentLoc(c.Loc); err != nil {
+		return err
+	}
+	if err := c.Naming.validate(); err != nil {
+		return errors.Wrapf(err, "component %q", c.Name)
+	}
+	if err := c.validateExt(); err != nil {
+		return errors.Wrapf(err, "component %q", c.Name)
+	}
+	if c.Lang != "" && !node.KnownLanguage(c.Lang) {
+		return fmt.Errorf("component %q: unknown language %q", c.Name, c.Lang)
+	}
 
-	n := p.lout.FindNode(c.Loc)
-	if n == nil {
-		return fmt.Errorf("%q does not exist", c.Loc)
+	names := make(map[string]bool, len(c.Params))
+	for _, prm := range c.Params {
+		if err := prm.validate(); err != nil {
+			return errors.Wrapf(err, "component %q", c.Name)
+		}
+		if names[prm.Name] {
+			return fmt.Errorf("component %q: duplicate parameter %q", c.Name, prm.Name)
+		}
+		names[prm.Name] = true
 	}
-	if _, ok := n.(node.Adder); !ok {
-		return fmt.Errorf("%q cannot have subnodes", c.Loc)
+
+	for _, o := range c.Outputs {
+		if o.Tmpl == nil {
+			return errComponentTemplateNil
+		}
+		if o.Pattern == "" {
+			return errEmptyOutputPattern
+		}
+		if err := p.checkComponentLoc(o.Loc); err != nil {
+			return err
+		}
+		if o.Lang != "" && !node.KnownLanguage(o.Lang) {
+			return fmt.Errorf("component %q: unknown language %q", c.Name, o.Lang)
+		}
 	}
 
 	p.components[c.Name] = c
@@ -171,51 +295,361 @@ func (p *Project) RegisterComponent(c Component) error {
 	return nil
 }
 
-// EmployComponent employs registered component to add new node to a project
-// layout.
-func (p *Project) EmployComponent(component, name string) error {
+// AddComponent registers a custom component and stores it in the project
+// notation, so the component is available the next time the project is
+// initialized. File of the component and its outputs is the template file
+// location, relative locations are resolved against the project location.
+// A component registered with the same name is replaced only when force is
+// set.
+func (p *Project) AddComponent(n chef.Component, force bool) error {
 	if !p.inited {
 		return errNotInited
 	}
+	if n.Name == "" {
+		return errEmptyComponentName
+	}
+	if _, ok := p.components[n.Name]; ok && !force {
+		return fmt.Errorf("component %q already registered", n.Name)
+	}
 
-	if strings.Index(name, ".") != strings.LastIndex(name, ".") {
-		return errInvalidNodeName
+	n.File = p.relLoc(p.resolveLoc(n.File))
+	outputs := n.Outputs
+	n.Outputs = nil
+	for _, o := range outputs {
+		o.File = p.relLoc(p.resolveLoc(o.File))
+		n.Outputs = append(n.Outputs, o)
 	}
 
-	nname, tname := name, name // node and template element name
+	c, err := p.customComponent(n)
+	if err != nil {
+		return err
+	}
 
-	// TODO (feat): extension should be configurable
-	switch ext := path.Ext(name); ext {
-	case "":
-		nname += defaultExt
-	case defaultExt:
-		tname = strings.TrimSuffix(name, defaultExt)
-	default:
-		return fmt.Errorf("unknown file extension %q", ext)
+	if err := p.RegisterComponent(c); err != nil {
+		return err
 	}
 
-	// TODO (feat): add node file extension based on project language preferences
+	if err := p.writeNotation(p.opts.out, p.loc); err != nil {
+		return errors.Wrap(err, "project notation write failed")
+	}
+	return nil
+}
+
+// customComponent creates a component from its notation.
+func (p *Project) customComponent(n chef.Component) (Component, error) {
+	tmpl, err := template.ParseFile(p.resolveLoc(n.File))
+	if err != nil {
+		return Component{}, errors.Wrapf(err, "component %q", n.Name)
+	}
+
+	c := Component{
+		Name:    n.Name,
+		Loc:     n.Loc,
+		Desc:    n.Desc,
+		Tmpl:    tmpl,
+		TmplLoc: n.File,
+		Ext:     n.Ext,
+		Lang:    n.Lang,
+		Naming:  Naming(n.Naming),
+	}
+
+	for _, o := range n.Outputs {
+		tmpl, err := template.ParseFile(p.resolveLoc(o.File))
+		if err != nil {
+			return Component{}, errors.Wrapf(err, "component %q", n.Name)
+		}
+		c.Outputs = append(c.Outputs, Output{
+			Loc:     o.Loc,
+			Pattern: o.Pattern,
+			Tmpl:    tmpl,
+			TmplLoc: o.File,
+			Lang:    o.Lang,
+		})
+	}
+
+	for _, prm := range n.Params {
+		c.Params = append(c.Params, Param{
+			Name:    prm.Name,
+			Type:    ParamType(prm.Type),
+			Default: prm.Default,
+			Values:  prm.Values,
+			Desc:    prm.Desc,
+		})
+	}
+	return c, nil
+}
+
+// checkComponentLoc checks that the component nodes can be added to the
+// location.
+func (p *Project) checkComponentLoc(loc string) error {
+	n := p.lout.FindNode(loc)
+	if n == nil {
+		return fmt.Errorf("%q does not exist", loc)
+	}
+	if _, ok := n.(node.Adder); !ok {
+		return fmt.Errorf("%q cannot have subnodes", loc)
+	}
+	return nil
+}
+
+// EmployComponent employs registered component to add new nodes to a project
+// layout. It returns locations of the created files relative to the project.
+func (p *Project) EmployComponent(component, name string, values map[string]string) ([]string, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	if err := p.validateName(component, name); err != nil {
+		return nil, err
+	}
+
+	nodes, data, err := p.componentNodes(component, name, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// The component nodes are employed as one unit: when any of the nodes
+	// cannot be added or built, layout and file system are not changed.
+	for i, cn := range nodes {
+		if err := p.lout.AddNode(cn.n, cn.loc); err != nil {
+			p.removeNodes(nodes[:i])
+			return nil, errors.Wrap(err, "failed to add node to layout")
+		}
+	}
+
+	staged, err := p.stageComponent(nodes, data)
+	if err != nil {
+		p.removeNodes(nodes)
+		return nil, err
+	}
+
+	created, err := p.writeStaged(staged)
+	if err != nil {
+		p.removeNodes(nodes)
+		return nil, err
+	}
+
+	main := nodes[0]
+	p.employed = append(p.employed, chef.Employed{
+		Component: component,
+		Name:      data.Name,
+		Path:      path.Join(main.loc, main.n.Name()),
+		Template:  main.n.Template().Name(),
+		Version:   template.Version(main.n.Template()),
+		Params:    data.values,
+	})
+	if err := p.writeNotation(p.opts.out, p.loc); err != nil {
+		// The notation does not record the component, thus its files and
+		// nodes are rolled back.
+		p.employed = p.employed[:len(p.employed)-1]
+		p.removeFiles(created)
+		p.removeNodes(nodes)
+		return nil, errors.Wrap(err, "project notation write failed")
+	}
+	p.own(component, nodes, data)
+	if err := p.updateBaseline(created, nil); err != nil {
+		return nil, errors.Wrap(err, "project baseline write failed")
+	}
+	return created, nil
+}
+
+// PlanComponent returns a list of entries employing of the component would
+// create. It does not change project layout and file system.
+func (p *Project) PlanComponent(component, name string, values map[string]string) ([]fsys.Entry, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	if err := p.validateName(component, name); err != nil {
+		return nil, err
+	}
+
+	nodes, data, err := p.componentNodes(component, name, values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cn := range nodes {
+		if p.lout.FindNode(path.Join(cn.loc, cn.n.Name())) != nil {
+			return nil, fmt.Errorf("node %q already exists in %q", cn.n.Name(), cn.loc)
+		}
+	}
+
+	mem, err := p.stageComponent(nodes, data)
+	if err != nil {
+		return nil, err
+	}
+	return mem.Entries(), nil
+}
+
+// stageComponent builds the component nodes in memory. It fails when any of
+// the node files already exists in the project.
+func (p *Project) stageComponent(nodes []componentNode, data componentData) (*fsys.MemFS, error) {
+	mem := fsys.NewMemFS()
+	for _, cn := range nodes {
+		loc := path.Join(cn.loc, cn.n.Name())
+		if _, err := p.opts.out.Stat(path.Join(p.loc, loc)); err == nil {
+			return nil, fmt.Errorf("file %q already exists", loc)
+		}
+		if err := cn.n.Build(mem, path.Join(p.loc, cn.loc), data); err != nil {
+			return nil, errors.Wrapf(err, "failed to build node %q", loc)
+		}
+	}
+	return mem, nil
+}
+
+// writeStaged writes the staged files to the project. When any of the files
+// cannot be written the already written files are removed. It returns
+// locations of the written files relative to the project.
+func (p *Project) writeStaged(staged *fsys.MemFS) ([]string, error) {
+	var written []string
+	for _, e := range staged.Entries() {
+		if e.Dir {
+			continue
+		}
+		err := func() error {
+			data, err := staged.FileContent(e.Path)
+			if err != nil {
+				return err
+			}
+			return p.opts.out.WriteFile(e.Path, data, e.Perm)
+		}()
+		if err != nil {
+			p.removeFiles(written)
+			return nil, errors.Wrapf(err, "failed to write %q", p.relLoc(e.Path))
+		}
+		written = append(written, p.relLoc(e.Path))
+	}
+	return written, nil
+}
+
+// removeFiles removes the files at the locations relative to the project. It's
+// a roll back of written files, thus errors are ignored.
+func (p *Project) removeFiles(locs []string) {
+	for _, loc := range locs {
+		_ = p.opts.out.RemoveAll(path.Join(p.loc, loc))
+	}
+}
+
+// removeNodes removes the component nodes from the project layout. It's a roll
+// back of added nodes, thus errors are ignored.
+func (p *Project) removeNodes(nodes []componentNode) {
+	for _, cn := range nodes {
+		_ = p.lout.RemoveNode(path.Join(cn.loc, cn.n.Name()))
+	}
+}
+
+// employedNode describes a layout node created by employing a component.
+type employedNode struct {
+	component string
+	data      componentData // data the node was built with
+}
+
+// own records the nodes created by employing the component.
+func (p *Project) own(component string, nodes []componentNode, data componentData) {
+	for _, cn := range nodes {
+		p.enodes[path.Join(cn.loc, cn.n.Name())] = employedNode{component: component, data: data}
+	}
+}
+
+// validateName checks the name of a new element of the registered component.
+func (p *Project) validateName(component, name string) error {
 	c, ok := p.components[component]
 	if !ok {
 		return fmt.Errorf("unregistered component %q", component)
 	}
+	return c.validateName(name)
+}
+
+// componentNode is a node created by employing a component.
+type componentNode struct {
+	loc string // location of the node in the project layout
+	n   *node.Fnode
+}
+
+// componentNodes creates new nodes of the registered component and the data
+// to build the nodes. The first node is the main component file, it is
+// followed by the nodes of the component outputs. Values are the raw values
+// of the component parameters.
+func (p *Project) componentNodes(component, name string, values map[string]string) ([]componentNode, componentData, error) {
+	c, ok := p.components[component]
+	if !ok {
+		return nil, componentData{}, fmt.Errorf("unregistered component %q", component)
+	}
+
+	nname, tname, err := c.elementName(name)
+	if err != nil {
+		return nil, componentData{}, err
+	}
+
+	params, raws, err := paramValues(c.Params, values)
+	if err != nil {
+		return nil, componentData{}, errors.Wrapf(err, "component %q", component)
+	}
+
+	data := componentData{
+		ProjectData: p.projectData(),
+		Name:        tname,
+		Path:        "/" + tname,
+		Params:      params,
+		values:      raws,
+	}
+	if v, ok := params[pathParam].(string); ok && v != "" {
+		data.Path = v
+	}
+
+	nodes := []componentNode{{loc: c.Loc, n: node.NewFnode(nname, node.WithTemplate(c.Tmpl), node.WithLanguage(c.Lang))}}
+	for _, o := range c.Outputs {
+		oname, err := o.filename(data)
+		if err != nil {
+			return nil, componentData{}, errors.Wrapf(err, "component %q", component)
+		}
+		n := node.NewFnode(oname, node.WithTemplate(o.Tmpl), node.WithLanguage(o.Lang))
+		nodes = append(nodes, componentNode{loc: o.Loc, n: n})
+	}
 
-	// TODO (feat): nodes should be added by name. File name extensions should be added
-	// at build time depending on template/component.
+	return nodes, data, nil
+}
 
-	n := node.NewFnode(nname, node.WithTemplate(c.Tmpl))
-	if err := p.lout.AddNode(n, c.Loc); err != nil {
-		return errors.Wrap(err, "failed to add node to layout")
+// Loc returns the project location.
+func (p *Project) Loc() string {
+	return p.loc
+}
+
+// Dependencies returns paths of the modules required in the project go.mod.
+// The requirements are not downloaded, go.sum is not generated.
+func (p *Project) Dependencies() []string {
+	if p.opts.mod == "" {
+		return nil
 	}
+	var paths []string
+	for _, d := range p.dependencies() {
+		paths = append(paths, d.Path)
+	}
+	return paths
+}
+
+// Paths returns locations of the project layout nodes relative to the project.
+// Directories go before their nodes.
+func (p *Project) Paths() []string {
+	return nodePaths(p.lout.Nodes(), "")
+}
 
-	data := struct {
-		Name, Path string
-	}{
-		Name: tname,
-		Path: "/" + tname,
+func nodePaths(nodes []node.Node, loc string) []string {
+	var paths []string
+	for _, n := range nodes {
+		nloc := path.Join(loc, n.Name())
+		paths = append(paths, nloc)
+		if d, ok := n.(*node.Dnode); ok {
+			paths = append(paths, nodePaths(d.Nodes(), nloc)...)
+		}
 	}
+	return paths
+}
 
-	return n.Build(path.Join(p.loc, c.Loc), data)
+// Layout returns the project layout.
+func (p *Project) Layout() *layout.Layout {
+	return p.lout
 }
 
 // Components returns a list of registered components sorted by component name.
@@ -235,13 +669,101 @@ func (p *Project) Components() []Component {
 	return components
 }
 
-func (p *Project) build() error {
-	var dp fs.FileMode = 0755
-	if err := os.Mkdir(p.loc, dp); err != nil {
+// buildAt builds project layout, notation and baseline at the location.
+func (p *Project) buildAt(out fsys.FS, loc string) error {
+	if err := p.build(out, loc); err != nil {
+		return errors.Wrap(err, "build failed")
+	}
+	// writeNotation should be called after project layout created
+	// notation failed saved to the root directory of the project
+	if err := p.writeNotation(out, loc); err != nil {
+		return errors.Wrap(err, "project notation write failed")
+	}
+	b := make(chef.Baseline)
+	if err := recordBaseline(b, out, loc, p.filePaths()); err != nil {
+		return errors.Wrap(err, "project baseline write failed")
+	}
+	if err := writeBaseline(out, loc, b); err != nil {
+		return errors.Wrap(err, "project baseline write failed")
+	}
+	return nil
+}
+
+func (p *Project) build(out fsys.FS, loc string) error {
+	if err := out.Mkdir(loc, projectPerm); err != nil {
 		return err
 	}
 
-	return p.lout.Build(p.loc, p.opts.mod)
+	return p.lout.Build(out, loc, p.projectData())
+}
+
+// componentData is the data to build a component node.
+type componentData struct {
+	template.ProjectData
+	Name   string                 // component element name
+	Path   string                 // component element path
+	Params map[string]interface{} // typed values of the component parameters
+
+	values map[string]string // raw values of the component parameters
+}
+
+func (p *Project) projectData() template.ProjectData {
+	return template.ProjectData{
+		Module:    p.opts.mod,
+		GoVersion: p.opts.gover,
+		Package:   template.Pkg(path.Base(p.name)),
+		Require:   p.dependencies(),
+	}
+}
+
+// dependencies returns the modules required by the project.
+func (p *Project) dependencies() []template.Dependency {
+	var deps []template.Dependency
+	deps = append(deps, dependencies[category(p.opts.cat)]...)
+	deps = append(deps, serverDependencies[server(p.opts.srv)]...)
+	return deps
+}
+
+// checkLocation checks that nothing exists at the project location.
+func (p *Project) checkLocation(out fsys.FS) error {
+	if _, err := out.Stat(p.loc); err == nil {
+		return fmt.Errorf("project location %q already exists", p.loc)
+	}
+	return nil
+}
+
+// claimLocation creates the project directory. It fails when anything exists at
+// the project location.
+func (p *Project) claimLocation(out fsys.FS) error {
+	if err := out.Mkdir(p.loc, projectPerm); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("project location %q already exists", p.loc)
+		}
+		return err
+	}
+	return nil
+}
+
+// moveStaged moves entries of the staging directory to the claimed project
+// directory and removes the staging directory.
+func (p *Project) moveStaged(out fsys.FS, staging string) error {
+	entries, err := out.DirEntries(staging)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := out.Rename(path.Join(staging, e.Name()), path.Join(p.loc, e.Name())); err != nil {
+			return err
+		}
+	}
+	return out.RemoveAll(staging)
+}
+
+// stagingLoc returns a unique location next to the project location to stage
+// the project build.
+func (p *Project) stagingLoc() string {
+	name := fmt.Sprintf(".%s.chef-%d", path.Base(p.loc), time.Now().UnixNano())
+	return path.Join(path.Dir(p.loc), name)
 }
 
 func (p *Project) setComponents() {
@@ -255,13 +777,60 @@ func (p *Project) setComponents() {
 	}
 }
 
+// setCustomComponents registers custom components stored in the project
+// notation.
+func (p *Project) setCustomComponents() error {
+	for _, n := range p.opts.comps {
+		if n.File == "" {
+			continue // components provided by chef
+		}
+		c, err := p.customComponent(n)
+		if err != nil {
+			return err
+		}
+		if err := p.checkComponentLoc(c.Loc); err != nil {
+			return errors.Wrapf(err, "component %q", c.Name)
+		}
+		p.components[c.Name] = c
+	}
+	return nil
+}
+
+// setEmployed adds the nodes of components employed in the project to the
+// project layout.
+func (p *Project) setEmployed() error {
+	for _, e := range p.opts.emps {
+		nodes, data, err := p.componentNodes(e.Component, e.Name, e.Params)
+		if err != nil {
+			return errors.Wrapf(err, "%q", e.Path)
+		}
+		for _, cn := range nodes {
+			if err := p.lout.AddNode(cn.n, cn.loc); err != nil {
+				return errors.Wrapf(err, "%q", e.Path)
+			}
+		}
+		p.own(e.Component, nodes, data)
+		p.employed = append(p.employed, e)
+	}
+	return nil
+}
+
 func (p *Project) setLayout() error {
 	if p.opts.lout != nil {
 		p.lout = p.opts.lout
 		return nil
 	}
 
-	f := layoutFactory(category(p.opts.cat), server(p.opts.srv))
+	if p.opts.ldef != nil {
+		l, err := layout.FromDefinition(*p.opts.ldef, template.Get, template.Funcs())
+		if err != nil {
+			return errors.Wrap(err, "layout definition")
+		}
+		p.lout = l
+		return nil
+	}
+
+	f := layoutFactory(category(p.opts.cat), server(p.opts.srv), path.Base(p.name))
 	if f == nil {
 		return fmt.Errorf("category %q: layout not found", p.opts.cat)
 	}
@@ -270,6 +839,33 @@ func (p *Project) setLayout() error {
 	return nil
 }
 
+// setGoMod adds go.mod file to the root of the project layout when module name
+// is set and the layout does not have go.mod.
+func (p *Project) setGoMod() error {
+	if p.opts.mod == "" || p.lout.FindNode(goModFile) != nil {
+		return nil
+	}
+	n := node.NewFnode(goModFile, node.WithTemplate(template.Get(template.GoMod)))
+	return p.lout.AddNode(n, layout.Root)
+}
+
+// setTemplates loads user templates. Relative templates location is resolved
+// against the project location.
+func (p *Project) setTemplates() error {
+	if p.opts.tmpls == "" {
+		return nil
+	}
+	return template.Load(p.resolveLoc(p.opts.tmpls))
+}
+
+// resolveLoc resolves relative location against the project location.
+func (p *Project) resolveLoc(loc string) string {
+	if filepath.IsAbs(loc) {
+		return loc
+	}
+	return filepath.Join(p.loc, loc)
+}
+
 func (p *Project) setLocation() error {
 	root := p.opts.root
 	if root == "" {
@@ -281,7 +877,7 @@ func (p *Project) setLocation() error {
 	}
 
 	// TODO: get full path of the root (currently it uses relative path)
-	fi, err := os.Stat(root)
+	fi, err := p.opts.out.Stat(root)
 	if err != nil {
 		return err
 	}
@@ -312,20 +908,80 @@ func (p *Project) validate() error {
 
 // writeNotation stores project notation to .chef.yml file after a project was
 // successfully built.
-func (p *Project) writeNotation() error {
+func (p *Project) writeNotation(out fsys.FS, loc string) error {
+	var buf bytes.Buffer
+	if err := p.notation().Write(&buf); err != nil {
+		return err
+	}
+
+	file := path.Join(loc, chef.DefaultNotationFileName)
+	return out.WriteFile(file, buf.Bytes(), notationPerm)
+}
+
+func (p *Project) notation() chef.Notation {
 	n := chef.Notation{
-		Category: p.opts.cat,
+		Category: category(p.opts.cat),
 		Server:   p.opts.srv,
 		Module:   p.opts.mod,
 	}
+	if p.opts.mod != "" {
+		n.GoVersion = p.opts.gover
+	}
+	if p.opts.tmpls != "" {
+		n.Templates = p.relLoc(p.resolveLoc(p.opts.tmpls))
+	}
+	n.Layout = &p.ldef
+	for _, c := range p.Components() {
+		nc := chef.Component{
+			Name:     c.Name,
+			Loc:      c.Loc,
+			Template: c.Tmpl.Name(),
+			File:     c.TmplLoc,
+			Desc:     c.Desc,
+			Ext:      c.Ext,
+			Lang:     c.Lang,
+			Naming:   string(c.Naming),
+		}
+		for _, o := range c.Outputs {
+			nc.Outputs = append(nc.Outputs, chef.Output{
+				Loc:      o.Loc,
+				Pattern:  o.Pattern,
+				Template: o.Tmpl.Name(),
+				File:     o.TmplLoc,
+				Lang:     o.Lang,
+			})
+		}
+		for _, prm := range c.Params {
+			nc.Params = append(nc.Params, chef.Param{
+				Name:    prm.Name,
+				Type:    string(prm.Type),
+				Default: prm.Default,
+				Values:  prm.Values,
+				Desc:    prm.Desc,
+			})
+		}
+		n.Components = append(n.Components, nc)
+	}
+	n.Employed = p.employed
+	return n
+}
 
-	file := path.Join(p.loc, chef.DefaultNotationFileName)
-	f, err := os.Create(file)
+// relLoc returns location relative to the project, or an absolute location if
+// relative cannot be found.
+func (p *Project) relLoc(loc string) string {
+	tloc, err := filepath.Abs(loc)
 	if err != nil {
-		return err
+		return loc
 	}
-
-	return n.Write(f)
+	ploc, err := filepath.Abs(p.loc)
+	if err != nil {
+		return tloc
+	}
+	rel, err := filepath.Rel(ploc, tloc)
+	if err != nil {
+		return tloc
+	}
+	return rel
 }
 
 // Option sets project options such as root location, category, etc.
@@ -373,6 +1029,31 @@ func WithModule(m string) Option {
 	})
 }
 
+// WithGoVersion returns an Option that sets Go version (for Go projects).
+func WithGoVersion(v string) Option {
+	return newFuncOption(func(o *projectOptions) {
+		if v = strings.TrimSpace(v); v != "" {
+			o.gover = v
+		}
+	})
+}
+
+// WithTemplates returns an Option that sets location of user templates.
+// Relative location is resolved against the project location.
+func WithTemplates(t string) Option {
+	return newFuncOption(func(o *projectOptions) {
+		o.tmpls = strings.TrimSpace(t)
+	})
+}
+
+// WithFS returns an Option that sets a file system the project is built to.
+// By default project is built to the OS file system.
+func WithFS(out fsys.FS) Option {
+	return newFuncOption(func(o *projectOptions) {
+		o.out = out
+	})
+}
+
 // WithLayout returns an Option that sets project layout.
 func WithLayout(l *layout.Layout) Option {
 	return newFuncOption(func(o *projectOptions) {
@@ -380,6 +1061,15 @@ func WithLayout(l *layout.Layout) Option {
 	})
 }
 
+// WithLayoutDefinition returns an Option that sets project layout definition.
+// The layout is created from the definition after project templates loaded,
+// so the definition can reference them.
+func WithLayoutDefinition(d layout.Definition) Option {
+	return newFuncOption(func(o *projectOptions) {
+		o.ldef = &d
+	})
+}
+
 // WithNotation returns an Option that sets project properties according to
 // provided notation.
 func WithNotation(n chef.Notation) Option {
@@ -387,5 +1077,12 @@ func WithNotation(n chef.Notation) Option {
 		o.cat = n.Category
 		o.srv = n.Server
 		o.mod = n.Module
+		o.tmpls = n.Templates
+		o.comps = n.Components
+		o.emps = n.Employed
+		o.ldef = n.Layout
+		if n.GoVersion != "" {
+			o.gover = n.GoVersion
+		}
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"text/template"
 
 	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 
 func TestProjectOptions(t *testing.T) {
 	tl := layout.New()
+	mem := fsys.NewMemFS()
 	testCases := []struct {
 		desc     string
 		opts     []Option
@@ -25,66 +27,121 @@ func TestProjectOptions(t *testing.T) {
 		{
 			desc: "project created with default options",
 			expected: projectOptions{
-				root: "",
-				cat:  "srv",
-				srv:  "",
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				gover: "1.23",
+				out:   fsys.OSFS{},
 			},
 		},
 		{
 			desc: "project created with the custom root",
 			opts: []Option{WithRoot("/r")},
 			expected: projectOptions{
-				root: "/r",
-				cat:  "srv",
-				srv:  "",
+				root:  "/r",
+				cat:   "srv",
+				srv:   "",
+				gover: "1.23",
+				out:   fsys.OSFS{},
 			},
 		},
 		{
 			desc: "project created with custom category",
 			opts: []Option{WithCategory("cli")},
 			expected: projectOptions{
-				root: "",
-				cat:  "cli",
-				srv:  "",
+				root:  "",
+				cat:   "cli",
+				srv:   "",
+				gover: "1.23",
+				out:   fsys.OSFS{},
 			},
 		},
 		{
 			desc: "project created with custom server",
 			opts: []Option{WithServer("http")},
 			expected: projectOptions{
-				root: "",
-				cat:  "srv",
-				srv:  "http",
+				root:  "",
+				cat:   "srv",
+				srv:   "http",
+				gover: "1.23",
+				out:   fsys.OSFS{},
 			},
 		},
 		{
 			desc: "project created with custom module",
 			opts: []Option{WithModule("cheftest")},
 			expected: projectOptions{
-				root: "",
-				cat:  "srv",
-				srv:  "",
-				mod:  "cheftest",
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				mod:   "cheftest",
+				gover: "1.23",
+				out:   fsys.OSFS{},
+			},
+		},
+		{
+			desc: "project created with custom Go version",
+			opts: []Option{WithGoVersion("1.22")},
+			expected: projectOptions{
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				gover: "1.22",
+				out:   fsys.OSFS{},
+			},
+		},
+		{
+			desc: "project created with custom templates",
+			opts: []Option{WithTemplates(" ./tmpl ")},
+			expected: projectOptions{
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				tmpls: "./tmpl",
+				gover: "1.23",
+				out:   fsys.OSFS{},
 			},
 		},
 		{
 			desc: "project created with custom layout",
 			opts: []Option{WithLayout(tl)},
 			expected: projectOptions{
-				root: "",
-				cat:  "srv",
-				srv:  "",
-				lout: tl,
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				lout:  tl,
+				gover: "1.23",
+				out:   fsys.OSFS{},
+			},
+		},
+		{
+			desc: "project created with custom file system",
+			opts: []Option{WithFS(mem)},
+			expected: projectOptions{
+				root:  "",
+				cat:   "srv",
+				srv:   "",
+				gover: "1.23",
+				out:   mem,
 			},
 		},
 		{
 			desc: "project created from notation",
-			opts: []Option{WithNotation(chef.Notation{Category: "srv", Server: "http", Module: "cheftest"})},
+			opts: []Option{WithNotation(chef.Notation{
+				Category:  "srv",
+				Server:    "http",
+				Module:    "cheftest",
+				GoVersion: "1.22",
+				Templates: "tmpl",
+			})},
 			expected: projectOptions{
-				root: "",
-				cat:  "srv",
-				srv:  "http",
-				mod:  "cheftest",
+				root:  "",
+				cat:   "srv",
+				srv:   "http",
+				mod:   "cheftest",
+				gover: "1.22",
+				tmpls: "tmpl",
+				out:   fsys.OSFS{},
 			},
 		},
 	}
@@ -1,19 +1,26 @@
 package project_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"text/template"
 
 	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/fsys"
 	"github.com/antklim/chef/internal/layout"
 	"github.com/antklim/chef/internal/layout/node"
 	"github.com/antklim/chef/internal/project"
+	templ "github.com/antklim/chef/internal/project/template"
 	testapi "github.com/antklim/chef/test/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestProjectInitFails(t *testing.T) {
@@ -50,12 +57,24 @@ func TestProjectInitFails(t *testing.T) {
 			opts: []project.Option{project.WithRoot("foo")},
 			err:  "set location failed: stat foo: no such file or directory",
 		},
+		{
+			desc: "when templates location does not exist",
+			name: "cheftest",
+			opts: []project.Option{project.WithRoot(tmpDir), project.WithTemplates("foo")},
+			err:  "set templates failed: stat " + path.Join(tmpDir, "cheftest", "foo") + ": no such file or directory",
+		},
 		{
 			desc: "when root is not a directory",
 			name: "cheftest",
 			opts: []project.Option{project.WithRoot(foofile)},
 			err:  `set location failed: "` + foofile + `" is not a directory`,
 		},
+		{
+			desc: "when root directory does not exist in the project file system",
+			name: "cheftest",
+			opts: []project.Option{project.WithRoot(tmpDir), project.WithFS(fsys.NewMemFS())},
+			err:  "set location failed: stat " + tmpDir + ": file does not exist",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -135,7 +154,7 @@ func TestProjectBuildFails(t *testing.T) {
 				err := p.Init()
 				return p, err
 			},
-			err: fmt.Sprintf("build failed: mkdir %s: file exists", ppath),
+			err: fmt.Sprintf("build failed: project location %q already exists", ppath),
 		},
 	}
 	for _, tC := range testCases {
@@ -150,6 +169,68 @@ func TestProjectBuildFails(t *testing.T) {
 	}
 }
 
+func TestProjectBuildRollback(t *testing.T) {
+	tmpDir := t.TempDir()
+	broken := node.NewFnode("router.go", node.WithNewTemplate("router", "package {{ .Foo }}"))
+	l := layout.New(
+		node.NewDnode("adapter"),
+		node.NewDnode("handler", node.WithSubNodes(node.NewDnode("http", node.WithSubNodes(broken)))),
+	)
+	p := project.New("project", project.WithRoot(tmpDir), project.WithLayout(l))
+	err := p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	assert.Error(t, err)
+	assert.Empty(t, loc)
+	assert.Contains(t, err.Error(), `build failed: failed to build node "handler": `+
+		`failed to build subnode "http": failed to build subnode "router.go": failed to execute template`)
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestProjectBuildClaimsLocation(t *testing.T) {
+	tmpDir := t.TempDir()
+	ppath := path.Join(tmpDir, "project")
+	p := project.New("project", project.WithRoot(tmpDir), project.WithFS(staleStatFS{stale: ppath}))
+	err := p.Init()
+	require.NoError(t, err)
+
+	// The location is created after the project initialized, stale Stat
+	// does not report it.
+	err = os.Mkdir(ppath, 0755)
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	assert.EqualError(t, err, fmt.Sprintf("build failed: project location %q already exists", ppath))
+	assert.Empty(t, loc)
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "project", entries[0].Name())
+
+	entries, err = os.ReadDir(ppath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// staleStatFS is an OS file system that reports that nothing exists at the
+// stale location.
+type staleStatFS struct {
+	fsys.OSFS
+	stale string
+}
+
+func (f staleStatFS) Stat(name string) (fs.FileInfo, error) {
+	if name == f.stale {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.OSFS.Stat(name)
+}
+
 func TestProjectBuild(t *testing.T) {
 	t.Run("builds project", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -176,6 +257,310 @@ func TestProjectBuild(t *testing.T) {
 		assert.Equal(t, "srv", n.Category)
 		assert.Equal(t, "project.git", n.Module)
 	})
+
+	t.Run("groups imports of dotless module apart from standard library", func(t *testing.T) {
+		root := t.TempDir()
+		mem := fsys.NewMemFS()
+		err := mem.Mkdir(root, 0755)
+		require.NoError(t, err)
+		p := project.New("project",
+			project.WithRoot(root),
+			project.WithServer("http"),
+			project.WithModule("nodot"),
+			project.WithFS(mem))
+		err = p.Init()
+		require.NoError(t, err)
+
+		loc, err := p.Build()
+		require.NoError(t, err)
+
+		data, err := fs.ReadFile(mem, path.Join(strings.TrimPrefix(loc, "/"), "server/http/server.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "import (\n\t\"log\"\n\t\"net/http\"\n\n\thandler \"nodot/handler/http\"\n)\n")
+	})
+}
+
+func TestProjectBuildPackage(t *testing.T) {
+	p := project.New("my-lib",
+		project.WithRoot(t.TempDir()),
+		project.WithCategory("package"),
+		project.WithModule("example.com/my-lib"))
+	err := p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	for _, name := range []string{"internal", "testdata", "mylib.go", "doc.go", "example_test.go", "go.mod"} {
+		_, err := os.Stat(path.Join(loc, name))
+		assert.NoError(t, err, name)
+	}
+
+	data, err := os.ReadFile(path.Join(loc, "mylib.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package mylib\n", string(data))
+
+	f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+	require.NoError(t, err)
+	defer f.Close()
+	n, err := chef.ReadNotation(f)
+	require.NoError(t, err)
+	assert.Equal(t, "pkg", n.Category)
+
+	_, err = p.EmployComponent("example", "parse", nil)
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(path.Join(loc, "parse_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "func Example_parse()")
+}
+
+func TestProjectBuildCLI(t *testing.T) {
+	root := t.TempDir()
+	mem := fsys.NewMemFS()
+	err := mem.Mkdir(root, 0755)
+	require.NoError(t, err)
+	p := project.New("tool",
+		project.WithRoot(root),
+		project.WithCategory("cli"),
+		project.WithModule("example.com/tool"),
+		project.WithFS(mem))
+	err = p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	require.NoError(t, err)
+	mloc := strings.TrimPrefix(loc, "/")
+
+	data, err := fs.ReadFile(mem, path.Join(mloc, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "require (\n\tgithub.com/spf13/cobra v1.8.1\n)\n")
+	assert.Equal(t, []string{"github.com/spf13/cobra"}, p.Dependencies())
+
+	expected := []string{"cmd", "cmd/tool", "cmd/tool/main.go", "internal", "internal/cli", "internal/cli/root.go", "internal/cli/flag.go", "go.mod"}
+	assert.Equal(t, expected, p.Paths())
+
+	data, err = fs.ReadFile(mem, path.Join(mloc, "cmd/tool/main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `import "example.com/tool/internal/cli"`)
+
+	created, err := p.EmployComponent("command", "deploy", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internal/cli/deploy.go"}, created)
+
+	data, err = fs.ReadFile(mem, path.Join(mloc, "internal/cli/deploy.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "rootCmd.AddCommand(deployCmd())")
+}
+
+func TestProjectBuildGRPC(t *testing.T) {
+	root := t.TempDir()
+	mem := fsys.NewMemFS()
+	err := mem.Mkdir(root, 0755)
+	require.NoError(t, err)
+	p := project.New("project",
+		project.WithRoot(root),
+		project.WithServer("grpc"),
+		project.WithModule("project.git"),
+		project.WithFS(mem))
+	err = p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	require.NoError(t, err)
+	mloc := strings.TrimPrefix(loc, "/")
+
+	data, err := fs.ReadFile(mem, path.Join(mloc, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"project.git/server/grpc"`)
+
+	data, err = fs.ReadFile(mem, path.Join(mloc, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "google.golang.org/grpc")
+
+	t.Run("employs grpc service", func(t *testing.T) {
+		entries, err := p.PlanComponent("grpc_service", "Echo", nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, path.Join(loc, "handler/grpc/Echo.go"), entries[0].Path)
+		assert.Equal(t, path.Join(loc, "proto/Echo.proto"), entries[1].Path)
+
+		created, err := p.EmployComponent("grpc_service", "Echo", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"handler/grpc/Echo.go", "proto/Echo.proto"}, created)
+
+		data, err := fs.ReadFile(mem, path.Join(mloc, "proto/Echo.proto"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "service Echo {")
+		assert.Contains(t, string(data), `option go_package = "project.git/proto";`)
+
+		data, err = fs.ReadFile(mem, path.Join(mloc, "handler/grpc/Echo.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "pb.RegisterEchoServer(s, &EchoServer{})")
+
+		_, err = p.EmployComponent("grpc_service", "Echo", nil)
+		assert.EqualError(t, err, `failed to add node to layout: failed to add node to "handler/grpc": node "Echo.go" already exists`)
+	})
+}
+
+func TestProjectBuildFS(t *testing.T) {
+	root := t.TempDir()
+	mem := fsys.NewMemFS()
+	err := mem.Mkdir(root, 0755)
+	require.NoError(t, err)
+	p := project.New("project",
+		project.WithRoot(root),
+		project.WithServer("http"),
+		project.WithModule("project.git"),
+		project.WithFS(mem))
+	err = p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	_, err = os.Stat(loc)
+	assert.True(t, os.IsNotExist(err))
+
+	mloc := strings.TrimPrefix(loc, "/")
+	data, err := fs.ReadFile(mem, path.Join(mloc, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"project.git/server/http"`)
+
+	_, err = fs.Stat(mem, path.Join(mloc, chef.DefaultNotationFileName))
+	assert.NoError(t, err)
+}
+
+func TestProjectBuildWithTemplates(t *testing.T) {
+	templ.KeepRegistered(t)
+	tmpDir := t.TempDir()
+	tmplDir := path.Join(tmpDir, "tmpl")
+	err := os.Mkdir(tmplDir, 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(tmplDir, "http_endpoint.tmpl"), []byte("package http // {{ .Name }}"), 0600)
+	require.NoError(t, err)
+
+	p := project.New("project",
+		project.WithRoot(tmpDir),
+		project.WithServer("http"),
+		project.WithTemplates(tmplDir))
+	err = p.Init()
+	require.NoError(t, err)
+
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	t.Run("records templates location relative to the project", func(t *testing.T) {
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+		assert.Equal(t, "../tmpl", n.Templates)
+	})
+
+	t.Run("employs components using user templates", func(t *testing.T) {
+		_, err := p.EmployComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(loc, "handler", "http", "echo.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package http // echo\n", string(data))
+	})
+}
+
+func TestProjectPlan(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		entries, err := p.Plan()
+		assert.EqualError(t, err, "project not inited")
+		assert.Nil(t, entries)
+	})
+
+	t.Run("fails when root contains file or directory with the project name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		ppath := path.Join(tmpDir, "project")
+		err := os.Mkdir(ppath, 0755)
+		require.NoError(t, err)
+
+		p := project.New("project", project.WithRoot(tmpDir))
+		err = p.Init()
+		require.NoError(t, err)
+
+		entries, err := p.Plan()
+		assert.EqualError(t, err, fmt.Sprintf("plan failed: project location %q already exists", ppath))
+		assert.Nil(t, entries)
+	})
+
+	t.Run("returns project entries without building project", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		p := project.New("project",
+			project.WithRoot(tmpDir),
+			project.WithServer("http"),
+			project.WithModule("project.git"))
+		err := p.Init()
+		require.NoError(t, err)
+
+		entries, err := p.Plan()
+		require.NoError(t, err)
+
+		loc := path.Join(tmpDir, "project")
+		var paths []string
+		for _, e := range entries {
+			paths = append(paths, e.Path)
+			if !e.Dir {
+				assert.NotZero(t, e.Size)
+			}
+		}
+		assert.Equal(t, loc, paths[0])
+		assert.Contains(t, paths, path.Join(loc, "handler", "http", "router.go"))
+		assert.Contains(t, paths, path.Join(loc, "main.go"))
+		assert.Contains(t, paths, path.Join(loc, chef.DefaultNotationFileName))
+
+		_, err = os.Stat(loc)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestProjectPlanComponent(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("cheftest")
+		entries, err := p.PlanComponent("http_handler", "echo", nil)
+		assert.EqualError(t, err, "project not inited")
+		assert.Nil(t, entries)
+	})
+
+	t.Run("fails when component with the given name already exists", func(t *testing.T) {
+		p, err := testapi.ProjectFactory(project.WithRoot(t.TempDir()))
+		require.NoError(t, err)
+		_, err = p.Build()
+		require.NoError(t, err)
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+
+		entries, err := p.PlanComponent("http_handler", "echo", nil)
+		assert.EqualError(t, err, `node "echo.go" already exists in "handler"`)
+		assert.Nil(t, entries)
+	})
+
+	t.Run("returns component entries without adding node", func(t *testing.T) {
+		p, err := testapi.ProjectFactory(project.WithRoot(t.TempDir()))
+		require.NoError(t, err)
+		loc, err := p.Build()
+		require.NoError(t, err)
+
+		entries, err := p.PlanComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, path.Join(loc, "handler", "echo.go"), entries[0].Path)
+
+		_, err = os.Stat(entries[0].Path)
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		assert.NoError(t, err)
+	})
 }
 
 func TestProjectRegisterComponentFails(t *testing.T) {
@@ -292,10 +677,187 @@ func TestProjectRegisterComponent(t *testing.T) {
 	}
 }
 
+func TestProjectAddComponent(t *testing.T) {
+	root := t.TempDir()
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"))
+	err := p.Init()
+	require.NoError(t, err)
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	err = os.Mkdir(path.Join(loc, "tmpl"), 0755)
+	require.NoError(t, err)
+	tmplFile := path.Join(loc, "tmpl", "repo.tmpl")
+	err = os.WriteFile(tmplFile, []byte("package provider\n\ntype {{ .Name }} struct{}\n"), 0600)
+	require.NoError(t, err)
+
+	t.Run("fails when component location does not exist", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "repo", Loc: "foo", File: tmplFile}, false)
+		assert.EqualError(t, err, `"foo" does not exist`)
+	})
+
+	t.Run("fails when component template is invalid", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: "tmpl/foo.tmpl"}, false)
+		assert.EqualError(t, err, `component "repo": open `+path.Join(loc, "tmpl/foo.tmpl")+`: no such file or directory`)
+	})
+
+	t.Run("fails when component naming is unknown", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: tmplFile, Naming: "camel"}, false)
+		assert.EqualError(t, err, `component "repo": unknown naming "camel"`)
+	})
+
+	t.Run("stores component in notation", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: tmplFile, Desc: "Repository"}, false)
+		require.NoError(t, err)
+
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+
+		expected := []chef.Component{
+			{Name: "http_handler", Loc: "handler/http", Template: "http_endpoint", Desc: "HTTP handler", Outputs: []chef.Output{
+				{Loc: "handler/http", Pattern: "{{.Name}}_test.go", Template: "http_endpoint_test"},
+			}, Params: []chef.Param{
+				{Name: "method", Type: "enum", Default: "GET", Values: []string{"GET", "POST", "PUT", "PATCH", "DELETE"}, Desc: "HTTP method of the endpoint"},
+				{Name: "path", Type: "string", Desc: "route of the endpoint, by default /<name>"},
+			}, Naming: "kebab"},
+			{Name: "repo", Loc: "provider", Template: "repo", File: "tmpl/repo.tmpl", Desc: "Repository"},
+		}
+		assert.Equal(t, expected, n.Components)
+
+		t.Run("and makes it available to the project", func(t *testing.T) {
+			p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+			err := p.Init()
+			require.NoError(t, err)
+
+			var names []string
+			for _, c := range p.Components() {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, []string{"http_handler", "repo"}, names)
+
+			_, err = p.EmployComponent("repo", "user", nil)
+			require.NoError(t, err)
+			data, err := os.ReadFile(path.Join(loc, "provider", "user.go"))
+			require.NoError(t, err)
+			assert.Equal(t, "package provider\n\ntype user struct{}\n", string(data))
+		})
+	})
+
+	t.Run("fails when component name is registered", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "http_handler", Loc: "provider", File: tmplFile}, false)
+		assert.EqualError(t, err, `component "http_handler" already registered`)
+		err = p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: tmplFile}, false)
+		assert.EqualError(t, err, `component "repo" already registered`)
+	})
+
+	t.Run("replaces registered component when forced", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "http_handler", Loc: "provider", File: tmplFile, Desc: "Handler"}, true)
+		require.NoError(t, err)
+
+		var found bool
+		for _, c := range p.Components() {
+			if c.Name == "http_handler" {
+				found = true
+				assert.Equal(t, "provider", c.Loc)
+				assert.Equal(t, "Handler", c.Desc)
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestProjectNotationState(t *testing.T) {
+	root := t.TempDir()
+	tmplFile := path.Join(root, "handler.tmpl")
+	err := os.WriteFile(tmplFile, []byte("package handler"), 0600)
+	require.NoError(t, err)
+
+	l := layout.New(
+		node.NewDnode("handler"),
+		node.NewFnode("README.md", node.WithNewTemplate("README.md", "# {{ .Module }}")),
+	)
+	p := project.New("project", project.WithRoot(root), project.WithLayout(l), project.WithModule("cheftest"))
+	err = p.Init()
+	require.NoError(t, err)
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	err = p.AddComponent(chef.Component{Name: "handler", Loc: "handler", File: tmplFile, Desc: "Handler"}, false)
+	require.NoError(t, err)
+	_, err = p.EmployComponent("handler", "echo", nil)
+	require.NoError(t, err)
+
+	readNotation := func(t *testing.T) chef.Notation {
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+		return n
+	}
+	n := readNotation(t)
+
+	t.Run("records layout without employed nodes", func(t *testing.T) {
+		expected := `nodes:
+- dir: handler
+  perm: "0755"
+- file: README.md
+  perm: "0644"
+  body: "# {{.Module}}"
+- file: go.mod
+  perm: "0644"
+  template: go_mod`
+		actual, err := yaml.Marshal(n.Layout)
+		require.NoError(t, err)
+		assert.YAMLEq(t, expected, string(actual))
+	})
+
+	t.Run("records registered components", func(t *testing.T) {
+		expected := []chef.Component{{Name: "handler", Loc: "handler", Template: "handler", File: "../handler.tmpl", Desc: "Handler"}}
+		assert.Equal(t, expected, n.Components)
+	})
+
+	t.Run("records employed components", func(t *testing.T) {
+		require.Len(t, n.Employed, 1)
+		e := n.Employed[0]
+		assert.Equal(t, "handler", e.Component)
+		assert.Equal(t, "echo", e.Name)
+		assert.Equal(t, "handler/echo.go", e.Path)
+		assert.Equal(t, "handler", e.Template)
+		assert.Len(t, e.Version, 12)
+	})
+
+	t.Run("reconstructs project state from notation", func(t *testing.T) {
+		p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+		err := p.Init()
+		require.NoError(t, err)
+		assert.NotNil(t, p.Layout().FindNode("README.md"))
+		assert.NotNil(t, p.Layout().FindNode("handler/echo.go"))
+
+		_, err = p.EmployComponent("handler", "echo", nil)
+		assert.EqualError(t, err, `failed to add node to layout: failed to add node to "handler": node "echo.go" already exists`)
+
+		_, err = p.EmployComponent("handler", "ping", nil)
+		require.NoError(t, err)
+		assert.Len(t, readNotation(t).Employed, 2)
+	})
+
+	t.Run("fails when employed component is not registered", func(t *testing.T) {
+		n := n
+		n.Components = nil
+		p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+		err := p.Init()
+		assert.EqualError(t, err, `set employed components failed: "handler/echo.go": unregistered component "handler"`)
+	})
+}
+
 func TestProjectEmployComponentFails(t *testing.T) {
 	t.Run("when project is not inited", func(t *testing.T) {
 		p := project.New("cheftest")
-		err := p.EmployComponent("http_handler", "echo.go")
+		_, err := p.EmployComponent("http_handler", "echo.go", nil)
 		assert.EqualError(t, err, "project not inited")
 	})
 
@@ -323,13 +885,25 @@ func TestProjectEmployComponentFails(t *testing.T) {
 			name: "echo.bravo.go",
 			err:  "periods not allowed in a file name",
 		},
+		{
+			desc: "when node name traverses path",
+			comp: "http_handler",
+			name: "../echo",
+			err:  `invalid name "../echo": must not contain path separators, try "echo"`,
+		},
+		{
+			desc: "when node name is a Go keyword",
+			comp: "http_handler",
+			name: "func",
+			err:  `invalid name "func": is a Go keyword, try "func_"`,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
 			p, err := testapi.ProjectFactory(project.WithRoot(t.TempDir()))
 			require.NoError(t, err)
 
-			err = p.EmployComponent(tC.comp, tC.name)
+			_, err = p.EmployComponent(tC.comp, tC.name, nil)
 			assert.EqualError(t, err, tC.err)
 		})
 	}
@@ -337,8 +911,9 @@ func TestProjectEmployComponentFails(t *testing.T) {
 	t.Run("when project layout does not exist", func(t *testing.T) {
 		p, err := testapi.ProjectFactory(project.WithRoot(t.TempDir()))
 		require.NoError(t, err)
-		err = p.EmployComponent("http_handler", "echo.go")
-		assert.True(t, os.IsNotExist(err))
+		_, err = p.EmployComponent("http_handler", "echo.go", nil)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.Nil(t, p.Layout().FindNode("handler/http/echo.go"))
 	})
 
 	t.Run("when component with the given name already exists", func(t *testing.T) {
@@ -347,12 +922,55 @@ func TestProjectEmployComponentFails(t *testing.T) {
 		_, err = p.Build()
 		require.NoError(t, err)
 
-		err = p.EmployComponent("http_handler", "echo")
+		_, err = p.EmployComponent("http_handler", "echo", nil)
 		assert.NoError(t, err)
 
-		err = p.EmployComponent("http_handler", "echo")
+		_, err = p.EmployComponent("http_handler", "echo", nil)
 		assert.EqualError(t, err, `failed to add node to layout: failed to add node to "handler": node "echo.go" already exists`)
 	})
+
+	t.Run("when project notation cannot be written", func(t *testing.T) {
+		root := t.TempDir()
+		out := &notationFailFS{MemFS: fsys.NewMemFS()}
+		err := out.Mkdir(root, 0755)
+		require.NoError(t, err)
+		p, err := testapi.ProjectFactory(project.WithRoot(root), project.WithFS(out))
+		require.NoError(t, err)
+		loc, err := p.Build()
+		require.NoError(t, err)
+
+		out.fail = true
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		assert.EqualError(t, err, "project notation write failed: notation write error")
+		assert.Nil(t, p.Layout().FindNode("handler/http/echo.go"))
+
+		_, err = out.Stat(path.Join(loc, "handler", "http", "echo.go"))
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		out.fail = false
+		_, err = p.EmployComponent("http_handler", "echo", nil)
+		require.NoError(t, err)
+
+		data, err := out.FileContent(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		n, err := chef.ReadNotation(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Len(t, n.Employed, 1)
+	})
+}
+
+// notationFailFS is a memory file system that fails to write project notation
+// when fail is set.
+type notationFailFS struct {
+	*fsys.MemFS
+	fail bool
+}
+
+func (f *notationFailFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if f.fail && path.Base(name) == chef.DefaultNotationFileName {
+		return errors.New("notation write error")
+	}
+	return f.MemFS.WriteFile(name, data, perm)
 }
 
 func TestProjectEmployComponent(t *testing.T) {
@@ -376,7 +994,7 @@ func TestProjectEmployComponent(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Empty(t, handlersDir)
 
-		err = p.EmployComponent("http_handler", "echo")
+		_, err = p.EmployComponent("http_handler", "echo", nil)
 		assert.NoError(t, err)
 
 		handlersDir, err = os.ReadDir(path.Join(loc, "handler"))
@@ -402,3 +1020,218 @@ func TestComponents(t *testing.T) {
 		assert.Equal(t, name, components[i].Name)
 	}
 }
+
+func TestProjectEmployMultiFileComponent(t *testing.T) {
+	root := t.TempDir()
+	tmplDir := path.Join(root, "tmpl")
+	require.NoError(t, os.Mkdir(tmplDir, 0755))
+	files := map[string]string{
+		"repo.tmpl":      "package provider\n\ntype {{ .Name }}Repo struct{}\n",
+		"repo_test.tmpl": "package test\n\n// {{ .Name }} repo test\n",
+		"broken.tmpl":    "package test\n\n{{ .Unknown.Field }}\n",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(path.Join(tmplDir, name), []byte(body), 0600))
+	}
+
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"), project.WithModule("project.git"))
+	require.NoError(t, p.Init())
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	err = p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: path.Join(tmplDir, "repo.tmpl"), Desc: "Repository",
+		Outputs: []chef.Output{{Loc: "test", Pattern: "{{.Name}}_repo_test.go", File: path.Join(tmplDir, "repo_test.tmpl")}}}, false)
+	require.NoError(t, err)
+
+	t.Run("creates all component files", func(t *testing.T) {
+		created, err := p.EmployComponent("repo", "user", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"provider/user.go", "test/user_repo_test.go"}, created)
+
+		data, err := os.ReadFile(path.Join(loc, "test/user_repo_test.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package test\n\n// user repo test\n", string(data))
+	})
+
+	t.Run("restores component files from notation", func(t *testing.T) {
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+
+		p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+		require.NoError(t, p.Init())
+		assert.NotNil(t, p.Layout().FindNode("test/user_repo_test.go"))
+	})
+
+	t.Run("does not create any file when one of the files fails", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "broken", Loc: "provider", File: path.Join(tmplDir, "repo.tmpl"),
+			Outputs: []chef.Output{{Loc: "test", Pattern: "{{.Name}}_test.go", File: path.Join(tmplDir, "broken.tmpl")}}}, false)
+		require.NoError(t, err)
+
+		_, err = p.EmployComponent("broken", "order", nil)
+		assert.ErrorContains(t, err, `failed to build node "test/order_test.go"`)
+
+		_, err = os.Stat(path.Join(loc, "provider/order.go"))
+		assert.True(t, os.IsNotExist(err))
+		assert.Nil(t, p.Layout().FindNode("provider/order.go"))
+		assert.Nil(t, p.Layout().FindNode("test/order_test.go"))
+	})
+
+	t.Run("fails when output pattern produces invalid file name", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "nested", Loc: "provider", File: path.Join(tmplDir, "repo.tmpl"),
+			Outputs: []chef.Output{{Loc: "test", Pattern: "{{.Name}}/test.go", File: path.Join(tmplDir, "repo_test.tmpl")}}}, false)
+		require.NoError(t, err)
+
+		_, err = p.EmployComponent("nested", "order", nil)
+		assert.EqualError(t, err, `component "nested": output pattern "{{.Name}}/test.go" produced invalid file name "order/test.go"`)
+	})
+}
+
+func TestProjectEmployComponentParams(t *testing.T) {
+	root := t.TempDir()
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"), project.WithModule("project.git"))
+	require.NoError(t, p.Init())
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc   string
+		values map[string]string
+		err    string
+	}{
+		{
+			desc:   "when parameter is unknown",
+			values: map[string]string{"verb": "GET"},
+			err:    `component "http_handler": unknown parameter "verb"`,
+		},
+		{
+			desc:   "when enum value is not allowed",
+			values: map[string]string{"method": "HEAD"},
+			err:    `component "http_handler": invalid value "HEAD" of parameter "method", expected one of GET, POST, PUT, PATCH, DELETE`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run("fails "+tC.desc, func(t *testing.T) {
+			_, err := p.EmployComponent("http_handler", "echo", tC.values)
+			assert.EqualError(t, err, tC.err)
+			assert.Nil(t, p.Layout().FindNode("handler/http/echo.go"))
+		})
+	}
+
+	t.Run("accepts kebab case names", func(t *testing.T) {
+		created, err := p.EmployComponent("http_handler", "user-profile", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"handler/http/user-profile.go", "handler/http/user-profile_test.go"}, created)
+	})
+
+	t.Run("uses default parameters values", func(t *testing.T) {
+		_, err := p.EmployComponent("http_handler", "health", nil)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(loc, "handler/http/health_test.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `httptest.NewRequest("GET"`)
+	})
+
+	t.Run("uses provided parameters values", func(t *testing.T) {
+		_, err := p.EmployComponent("http_handler", "echo", map[string]string{"method": "POST", "path": "/v1/echo"})
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(loc, "handler/http/echo_test.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `httptest.NewRequest("POST"`)
+	})
+
+	t.Run("restores parameters values from notation", func(t *testing.T) {
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+
+		require.Len(t, n.Employed, 3)
+		assert.Equal(t, map[string]string{"method": "GET", "path": ""}, n.Employed[1].Params)
+		assert.Equal(t, map[string]string{"method": "POST", "path": "/v1/echo"}, n.Employed[2].Params)
+
+		p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+		require.NoError(t, p.Init())
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestProjectEmployNonGoComponent(t *testing.T) {
+	root := t.TempDir()
+	tmplDir := path.Join(root, "tmpl")
+	require.NoError(t, os.Mkdir(tmplDir, 0755))
+	files := map[string]string{
+		"migration.tmpl": "CREATE TABLE {{ .Name | trimPrefix \"create_\" }} ();\n",
+		"config.tmpl":    "name: {{ .Name }}\n  key: value\n",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(path.Join(tmplDir, name), []byte(body), 0600))
+	}
+
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"), project.WithModule("project.git"))
+	require.NoError(t, p.Init())
+	loc, err := p.Build()
+	require.NoError(t, err)
+
+	t.Run("fails when component extension is invalid", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "migration", Loc: "provider", File: path.Join(tmplDir, "migration.tmpl"), Ext: "sql"}, false)
+		assert.EqualError(t, err, `component "migration": invalid extension "sql"`)
+	})
+
+	t.Run("fails when component language is unknown", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "migration", Loc: "provider", File: path.Join(tmplDir, "migration.tmpl"), Lang: "rust"}, false)
+		assert.EqualError(t, err, `component "migration": unknown language "rust"`)
+	})
+
+	err = p.AddComponent(chef.Component{Name: "migration", Loc: "provider", File: path.Join(tmplDir, "migration.tmpl"), Ext: ".up.sql"}, false)
+	require.NoError(t, err)
+
+	t.Run("creates file with compound extension", func(t *testing.T) {
+		created, err := p.EmployComponent("migration", "create_users", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"provider/create_users.up.sql"}, created)
+
+		data, err := os.ReadFile(path.Join(loc, "provider/create_users.up.sql"))
+		require.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE users ();\n", string(data))
+	})
+
+	t.Run("accepts name with compound extension", func(t *testing.T) {
+		created, err := p.EmployComponent("migration", "create_orders.up.sql", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"provider/create_orders.up.sql"}, created)
+	})
+
+	t.Run("restores component from notation", func(t *testing.T) {
+		f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := chef.ReadNotation(f)
+		require.NoError(t, err)
+
+		p := project.New("project", project.WithRoot(root), project.WithNotation(n))
+		require.NoError(t, p.Init())
+		assert.NotNil(t, p.Layout().FindNode("provider/create_users.up.sql"))
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("checks rendered content of the component language", func(t *testing.T) {
+		err := p.AddComponent(chef.Component{Name: "config", Loc: "provider", File: path.Join(tmplDir, "config.tmpl"),
+			Ext: ".tmpl", Lang: "yaml", Naming: "file"}, false)
+		require.NoError(t, err)
+
+		_, err = p.EmployComponent("config", "app", nil)
+		assert.ErrorContains(t, err, `failed to build node "provider/app.tmpl": template "config" rendered invalid code`)
+		assert.Nil(t, p.Layout().FindNode("provider/app.tmpl"))
+	})
+}
@@ -0,0 +1,152 @@
+package project
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+)
+
+// SyncReport describes the changes made by the project sync.
+type SyncReport struct {
+	Created     []string // locations of created directories and files relative to the project
+	Overwritten []string // locations of overwritten files relative to the project
+}
+
+// syncEntry is a file system entry the project sync writes.
+type syncEntry struct {
+	loc    string // location relative to the project
+	dir    bool
+	perm   fs.FileMode
+	data   []byte
+	exists bool // file exists and it is overwritten
+}
+
+// Sync creates the project layout nodes missing in the file system, including
+// the nodes of employed components. Existing directories and files are not
+// changed, unless overwrite is set: then files which content differs from a
+// fresh render are generated again. All files are rendered before any change
+// is made. The content of written files is recorded in the project baseline.
+func (p *Project) Sync(overwrite bool) (SyncReport, error) {
+	if !p.inited {
+		return SyncReport{}, errNotInited
+	}
+
+	entries, err := p.syncEntries(overwrite)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	out := p.opts.out
+	var report SyncReport
+	var written []string
+	for _, e := range entries {
+		loc := path.Join(p.loc, e.loc)
+		if e.dir {
+			if err := out.Mkdir(loc, e.perm); err != nil {
+				return report, errors.Wrapf(err, "failed to create directory %q", e.loc)
+			}
+		} else {
+			if err := out.WriteFile(loc, e.data, e.perm); err != nil {
+				return report, errors.Wrapf(err, "failed to write file %q", e.loc)
+			}
+			written = append(written, e.loc)
+		}
+
+		if e.exists {
+			report.Overwritten = append(report.Overwritten, e.loc)
+		} else {
+			report.Created = append(report.Created, e.loc)
+		}
+	}
+
+	if len(written) > 0 {
+		if err := p.updateBaseline(written, nil); err != nil {
+			return report, errors.Wrap(err, "project baseline write failed")
+		}
+	}
+	return report, nil
+}
+
+// PlanSync returns a list of entries the project sync would write. It does not
+// change the file system.
+func (p *Project) PlanSync(overwrite bool) ([]fsys.Entry, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	entries, err := p.syncEntries(overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := make([]fsys.Entry, 0, len(entries))
+	for _, e := range entries {
+		planned = append(planned, fsys.Entry{
+			Path: path.Join(p.loc, e.loc),
+			Perm: e.perm,
+			Size: int64(len(e.data)),
+			Dir:  e.dir,
+		})
+	}
+	return planned, nil
+}
+
+// syncEntries returns the entries the project sync writes, parent directories
+// precede their entries.
+func (p *Project) syncEntries(overwrite bool) ([]syncEntry, error) {
+	fi, err := p.opts.out.Stat(p.loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "sync failed")
+	}
+	if !fi.IsDir() {
+		return nil, errors.Errorf("sync failed: %q is not a directory", p.loc)
+	}
+	return p.syncNodes(p.lout.Nodes(), "", overwrite)
+}
+
+func (p *Project) syncNodes(nodes []node.Node, loc string, overwrite bool) ([]syncEntry, error) {
+	var entries []syncEntry
+	for _, n := range nodes {
+		nloc := path.Join(loc, n.Name())
+		fi, err := p.opts.out.Stat(path.Join(p.loc, nloc))
+		exists := err == nil
+
+		switch n := n.(type) {
+		case *node.Dnode:
+			if exists && !fi.IsDir() {
+				continue // the file in place of the directory is left as is
+			}
+			if !exists {
+				entries = append(entries, syncEntry{loc: nloc, dir: true, perm: n.Perm()})
+			}
+			subentries, err := p.syncNodes(n.Nodes(), nloc, overwrite)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, subentries...)
+		case *node.Fnode:
+			if exists && (!overwrite || fi.IsDir()) {
+				continue
+			}
+			data, err := n.Render(p.nodeData(nloc))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to build node %q", nloc)
+			}
+			if exists {
+				actual, err := p.opts.out.FileContent(path.Join(p.loc, nloc))
+				if err != nil {
+					return nil, err
+				}
+				if bytes.Equal(data, actual) {
+					continue
+				}
+			}
+			entries = append(entries, syncEntry{loc: nloc, perm: n.Perm(), data: data, exists: exists})
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,137 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncedProject returns a project with employed component restored from the
+// notation of the built project.
+func syncedProject(t *testing.T) (*project.Project, string) {
+	root := t.TempDir()
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"), project.WithModule("project.git"))
+	require.NoError(t, p.Init())
+	loc, err := p.Build()
+	require.NoError(t, err)
+	_, err = p.EmployComponent("http_handler", "echo", map[string]string{"method": "POST"})
+	require.NoError(t, err)
+
+	f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+	require.NoError(t, err)
+	defer f.Close()
+	n, err := chef.ReadNotation(f)
+	require.NoError(t, err)
+
+	p = project.New("project", project.WithRoot(root), project.WithNotation(n))
+	require.NoError(t, p.Init())
+	return p, loc
+}
+
+func TestProjectSync(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.Sync(false)
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("fails when project does not exist", func(t *testing.T) {
+		p := project.New("project", project.WithRoot(t.TempDir()), project.WithServer("http"))
+		require.NoError(t, p.Init())
+
+		_, err := p.Sync(false)
+		assert.ErrorContains(t, err, "sync failed:")
+	})
+
+	t.Run("does not change project in sync with layout", func(t *testing.T) {
+		p, _ := syncedProject(t)
+		report, err := p.Sync(false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Created)
+		assert.Empty(t, report.Overwritten)
+	})
+
+	t.Run("creates missing nodes", func(t *testing.T) {
+		p, loc := syncedProject(t)
+		require.NoError(t, os.RemoveAll(path.Join(loc, "handler")))
+		require.NoError(t, os.Remove(path.Join(loc, "go.mod")))
+
+		report, err := p.Sync(false)
+		require.NoError(t, err)
+		expected := []string{
+			"handler",
+			"handler/http",
+			"handler/http/router.go",
+			"handler/http/echo.go",
+			"handler/http/echo_test.go",
+			"go.mod",
+		}
+		assert.ElementsMatch(t, expected, report.Created)
+		assert.Empty(t, report.Overwritten)
+
+		data, err := os.ReadFile(path.Join(loc, "handler/http/echo_test.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `httptest.NewRequest("POST"`)
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("leaves modified files untouched", func(t *testing.T) {
+		p, loc := syncedProject(t)
+		file := path.Join(loc, "handler/http/echo.go")
+		require.NoError(t, os.WriteFile(file, []byte("package http\n"), 0644))
+
+		report, err := p.Sync(false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Created)
+		assert.Empty(t, report.Overwritten)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "package http\n", string(data))
+	})
+
+	t.Run("overwrites modified files", func(t *testing.T) {
+		p, loc := syncedProject(t)
+		file := path.Join(loc, "handler/http/echo.go")
+		require.NoError(t, os.WriteFile(file, []byte("package http\n"), 0644))
+
+		report, err := p.Sync(true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Created)
+		assert.Equal(t, []string{"handler/http/echo.go"}, report.Overwritten)
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestProjectPlanSync(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.PlanSync(false)
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("returns entries without changing file system", func(t *testing.T) {
+		p, loc := syncedProject(t)
+		require.NoError(t, os.RemoveAll(path.Join(loc, "test")))
+
+		entries, err := p.PlanSync(false)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, path.Join(loc, "test"), entries[0].Path)
+		assert.True(t, entries[0].Dir)
+
+		_, err = os.Stat(path.Join(loc, "test"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
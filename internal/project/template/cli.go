@@ -0,0 +1,105 @@
+package template
+
+import "text/template"
+
+var _ = template.Must(rootTemplate.New(CLIMain).Parse(`package main
+
+import "{{ .Module }}/internal/cli"
+
+func main() {
+	cli.Execute()
+}
+`))
+
+var _ = template.Must(rootTemplate.New(CLIRoot).Parse(`package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// rootCmd is the root command of the application. Subcommands add themselves
+// to the root command in their init functions.
+var rootCmd = &cobra.Command{
+	Use:           "{{ .Package }}",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Short:         "TODO: describe {{ .Package }}",
+}
+
+// Execute is the primary entrypoint of the CLI app.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+var _ = template.Must(rootTemplate.New(CLIFlag).Parse(`package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// Flag describes a command flag.
+type Flag struct {
+	LongForm   string
+	ShortForm  string
+	Help       string
+	IsRequired bool
+}
+
+// RegisterString registers string flag in the command.
+func (f *Flag) RegisterString(cmd *cobra.Command, value *string, defaultValue string) {
+	cmd.Flags().StringVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+	f.markRequired(cmd)
+}
+
+// RegisterBool registers bool flag in the command.
+func (f *Flag) RegisterBool(cmd *cobra.Command, value *bool, defaultValue bool) {
+	cmd.Flags().BoolVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+	f.markRequired(cmd)
+}
+
+func (f *Flag) markRequired(cmd *cobra.Command) {
+	if !f.IsRequired {
+		return
+	}
+	if err := cmd.MarkFlagRequired(f.LongForm); err != nil {
+		panic(fmt.Errorf("failed to register flag %q: %w", f.LongForm, err))
+	}
+}
+`))
+
+var _ = template.Must(rootTemplate.New(CLICommand).Parse(`
+{{- $cmd := print (camel .Name) "Cmd" | ident -}}
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand({{ $cmd }}())
+}
+
+func {{ $cmd }}() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "{{ .Name | kebab }}",
+		Short: "TODO: describe {{ .Name | kebab }}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(cmd.OutOrStdout(), "{{ .Name | kebab }} called")
+			return nil
+		},
+	}
+
+	return cmd
+}
+`))
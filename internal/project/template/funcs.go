@@ -0,0 +1,312 @@
+package template
+
+import (
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// funcs is the function library available to all templates.
+var funcs = template.FuncMap{
+	// case conversions
+	"camel":  Camel,
+	"pascal": Pascal,
+	"snake":  Snake,
+	"kebab":  Kebab,
+
+	// inflections
+	"plural":   Plural,
+	"singular": Singular,
+
+	// identifiers and import paths
+	"ident":      Ident,
+	"pkg":        Pkg,
+	"importPath": importPath,
+	"base":       path.Base,
+	"dir":        path.Dir,
+
+	// string utilities, the string argument goes last to support pipelines
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"title":      title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+	"quote":      strconv.Quote,
+}
+
+// Funcs returns the function library available to all templates. Templates
+// parsed outside of the registry, like file name patterns, should use it.
+func Funcs() template.FuncMap {
+	fm := make(template.FuncMap, len(funcs))
+	for name, fn := range funcs {
+		fm[name] = fn
+	}
+	return fm
+}
+
+// initialisms are written in the same case by Go naming conventions.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DB": true, "DNS": true, "EOF": true,
+	"GRPC": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "JWT": true, "OS": true, "RPC": true, "SQL": true,
+	"SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"URI": true, "URL": true, "UUID": true, "XML": true, "YAML": true,
+}
+
+// words splits s into words. Words are separated by any character other than
+// a letter or a digit and by case changes, for example "userID", "user_id"
+// and "user-id" are split into "user" and "id".
+func words(s string) []string {
+	var (
+		ws   []string
+		word []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			ws = append(ws, string(word))
+			word = word[:0]
+		}
+	}
+
+	rs := []rune(s)
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			// "userID" splits before "I", "HTTPServer" splits before "S"
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return ws
+}
+
+// Camel converts s to camel case, for example "user-id" to "userID".
+func Camel(s string) string {
+	ws := words(s)
+	var b strings.Builder
+	for i, w := range ws {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w))
+			continue
+		}
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+// Pascal converts s to pascal case, for example "user-id" to "UserID".
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+// Snake converts s to snake case, for example "UserID" to "user_id".
+func Snake(s string) string {
+	return strings.ToLower(strings.Join(words(s), "_"))
+}
+
+// Kebab converts s to kebab case, for example "UserID" to "user-id".
+func Kebab(s string) string {
+	return strings.ToLower(strings.Join(words(s), "-"))
+}
+
+func capitalize(w string) string {
+	if u := strings.ToUpper(w); initialisms[u] {
+		return u
+	}
+	return title(strings.ToLower(w))
+}
+
+func title(s string) string {
+	rs := []rune(s)
+	if len(rs) == 0 {
+		return s
+	}
+	rs[0] = unicode.ToUpper(rs[0])
+	return string(rs)
+}
+
+// irregulars are singular and plural forms of irregular nouns.
+var irregulars = [][2]string{
+	{"person", "people"},
+	{"child", "children"},
+	{"mouse", "mice"},
+	{"index", "indices"},
+}
+
+// uncountables are nouns with the same singular and plural forms.
+var uncountables = []string{"data", "info", "metadata", "news", "series", "species", "equipment"}
+
+// Plural returns plural form of the last word of s, for example "category" to
+// "categories" and "UserProfile" to "UserProfiles".
+func Plural(s string) string {
+	lower := strings.ToLower(s)
+	for _, w := range uncountables {
+		if strings.HasSuffix(lower, w) {
+			return s
+		}
+	}
+	for _, irr := range irregulars {
+		if strings.HasSuffix(lower, irr[0]) {
+			return replaceSuffix(s, len(irr[0]), irr[1])
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "y") && !endsWithVowel(lower[:len(lower)-1]):
+		return replaceSuffix(s, 1, "ies")
+	case hasAnySuffix(lower, "s", "x", "z", "ch", "sh"):
+		return s + matchCase("es", s)
+	case s == "":
+		return s
+	}
+	return s + matchCase("s", s)
+}
+
+// Singular returns singular form of the last word of s, for example
+// "categories" to "category" and "UserProfiles" to "UserProfile".
+func Singular(s string) string {
+	lower := strings.ToLower(s)
+	for _, w := range uncountables {
+		if strings.HasSuffix(lower, w) {
+			return s
+		}
+	}
+	for _, irr := range irregulars {
+		if strings.HasSuffix(lower, irr[1]) {
+			return replaceSuffix(s, len(irr[1]), irr[0])
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return replaceSuffix(s, 3, "y")
+	case hasAnySuffix(lower, "sses", "xes", "zes", "ches", "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(lower, "uses") && len(lower) > 4 && !endsWithVowel(lower[:len(lower)-4]):
+		// "statuses" and "buses", but "causes" and "houses"
+		return s[:len(s)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"):
+		return s
+	case strings.HasSuffix(lower, "s"):
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// replaceSuffix replaces n last bytes of s with the lower case suffix,
+// following the case of the replaced part.
+func replaceSuffix(s string, n int, suffix string) string {
+	old := s[len(s)-n:]
+	switch {
+	case old == strings.ToUpper(old) && strings.ToUpper(old) != strings.ToLower(old):
+		suffix = strings.ToUpper(suffix)
+	case unicode.IsUpper([]rune(old)[0]):
+		suffix = title(suffix)
+	}
+	return s[:len(s)-n] + suffix
+}
+
+// matchCase returns suffix in upper case when s is all upper case.
+func matchCase(suffix, s string) string {
+	if s == strings.ToUpper(s) && s != strings.ToLower(s) {
+		return strings.ToUpper(suffix)
+	}
+	return suffix
+}
+
+func endsWithVowel(s string) bool {
+	return hasAnySuffix(s, "a", "e", "i", "o", "u")
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ident returns a valid Go identifier from s. Invalid characters are dropped,
+// the letter following them is capitalized, for example "user-profile" becomes
+// "userProfile". Identifiers starting with a digit are prefixed with "_" and Go
+// keywords are suffixed with "_".
+func Ident(s string) string {
+	var (
+		b     strings.Builder
+		upper bool
+	)
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			upper = true
+			continue
+		}
+		if upper && b.Len() > 0 {
+			r = unicode.ToUpper(r)
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+
+	id := b.String()
+	switch {
+	case id == "":
+		return "_"
+	case unicode.IsDigit([]rune(id)[0]):
+		return "_" + id
+	case token.IsKeyword(id):
+		return id + "_"
+	}
+	return id
+}
+
+// Pkg returns a valid Go package name from s: lower case letters and digits
+// starting with a letter, for example "user-profile" becomes "userprofile".
+// Go keywords are suffixed with "pkg".
+func Pkg(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r):
+			b.WriteRune(r)
+		case unicode.IsDigit(r) && b.Len() > 0:
+			b.WriteRune(r)
+		}
+	}
+
+	name := b.String()
+	if name == "" || token.IsKeyword(name) {
+		return name + "pkg"
+	}
+	return name
+}
+
+// importPath joins the module path and the package directories into an
+// import path, for example "example.com/app" and "handler/http" become
+// "example.com/app/handler/http".
+func importPath(module string, elem ...string) string {
+	return path.Join(append([]string{module}, elem...)...)
+}
@@ -0,0 +1,132 @@
+package template_test
+
+import (
+	"bytes"
+	"testing"
+	gotemplate "text/template"
+
+	"github.com/antklim/chef/internal/project/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaseConversions(t *testing.T) {
+	testCases := []struct {
+		in     string
+		camel  string
+		pascal string
+		snake  string
+		kebab  string
+	}{
+		{in: "user", camel: "user", pascal: "User", snake: "user", kebab: "user"},
+		{in: "user-profile", camel: "userProfile", pascal: "UserProfile", snake: "user_profile", kebab: "user-profile"},
+		{in: "user_id", camel: "userID", pascal: "UserID", snake: "user_id", kebab: "user-id"},
+		{in: "HTTPServer", camel: "httpServer", pascal: "HTTPServer", snake: "http_server", kebab: "http-server"},
+		{in: "orderItems v2", camel: "orderItemsV2", pascal: "OrderItemsV2", snake: "order_items_v2", kebab: "order-items-v2"},
+		{in: "", camel: "", pascal: "", snake: "", kebab: ""},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			assert.Equal(t, tC.camel, template.Camel(tC.in))
+			assert.Equal(t, tC.pascal, template.Pascal(tC.in))
+			assert.Equal(t, tC.snake, template.Snake(tC.in))
+			assert.Equal(t, tC.kebab, template.Kebab(tC.in))
+		})
+	}
+}
+
+func TestInflections(t *testing.T) {
+	testCases := []struct {
+		singular string
+		plural   string
+	}{
+		{singular: "user", plural: "users"},
+		{singular: "category", plural: "categories"},
+		{singular: "key", plural: "keys"},
+		{singular: "address", plural: "addresses"},
+		{singular: "status", plural: "statuses"},
+		{singular: "bus", plural: "buses"},
+		{singular: "Virus", plural: "Viruses"},
+		{singular: "cause", plural: "causes"},
+		{singular: "house", plural: "houses"},
+		{singular: "purpose", plural: "purposes"},
+		{singular: "box", plural: "boxes"},
+		{singular: "branch", plural: "branches"},
+		{singular: "person", plural: "people"},
+		{singular: "UserProfile", plural: "UserProfiles"},
+		{singular: "SalesPerson", plural: "SalesPeople"},
+		{singular: "data", plural: "data"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.singular, func(t *testing.T) {
+			assert.Equal(t, tC.plural, template.Plural(tC.singular))
+			assert.Equal(t, tC.singular, template.Singular(tC.plural))
+		})
+	}
+}
+
+func TestIdent(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{in: "user", expected: "user"},
+		{in: "UserProfile", expected: "UserProfile"},
+		{in: "user-profile", expected: "userProfile"},
+		{in: "user.profile v2", expected: "userProfileV2"},
+		{in: "2fa", expected: "_2fa"},
+		{in: "type", expected: "type_"},
+		{in: "typeRoute", expected: "typeRoute"},
+		{in: "--", expected: "_"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			assert.Equal(t, tC.expected, template.Ident(tC.in))
+		})
+	}
+}
+
+func TestPkg(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{in: "user", expected: "user"},
+		{in: "User-Profile", expected: "userprofile"},
+		{in: "2fa", expected: "fa"},
+		{in: "go", expected: "gopkg"},
+		{in: "", expected: "pkg"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			assert.Equal(t, tC.expected, template.Pkg(tC.in))
+		})
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	testCases := []struct {
+		tmpl     string
+		expected string
+	}{
+		{tmpl: `{{ .Name | pascal | plural }}`, expected: "UserProfiles"},
+		{tmpl: `{{ importPath .Module "handler" "http" }}`, expected: "example.com/app/handler/http"},
+		{tmpl: `{{ importPath .Module "handler/http" | base }}`, expected: "http"},
+		{tmpl: `{{ .Name | replace "-" "." | upper }}`, expected: "USER.PROFILE"},
+		{tmpl: `{{ .Name | trimSuffix "-profile" | title }}`, expected: "User"},
+		{tmpl: `{{ .Name | split "-" | join "/" }}`, expected: "user/profile"},
+		{tmpl: `{{ if .Name | hasPrefix "user" }}{{ .Name | quote }}{{ end }}`, expected: `"user-profile"`},
+	}
+	data := struct{ Name, Module string }{Name: "user-profile", Module: "example.com/app"}
+	for _, tC := range testCases {
+		t.Run(tC.tmpl, func(t *testing.T) {
+			tmpl, err := gotemplate.New("").Funcs(template.Funcs()).Parse(tC.tmpl)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			err = tmpl.Execute(&out, data)
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package template
+
+import "text/template"
+
+var _ = template.Must(rootTemplate.New(GoMod).Parse(`module {{ .Module }}
+
+go {{ .GoVersion }}
+{{- with .Require }}
+
+require (
+{{- range . }}
+	{{ .Path }} {{ .Version }}
+{{- end }}
+)
+{{- end }}
+`))
@@ -0,0 +1,105 @@
+package template
+
+import "text/template"
+
+var _ = template.Must(rootTemplate.New(GRPCProto).Parse(`syntax = "proto3";
+
+package {{ .Package }};
+
+option go_package = "{{ .Module }}/proto";
+
+service {{ .Name | pascal | ident }} {
+  rpc Ping({{ .Name | pascal | ident }}PingRequest) returns ({{ .Name | pascal | ident }}PingResponse);
+}
+
+message {{ .Name | pascal | ident }}PingRequest {}
+
+message {{ .Name | pascal | ident }}PingResponse {}
+`))
+
+var _ = template.Must(rootTemplate.New(GRPCHandler).Parse(`package grpc
+
+import (
+	"google.golang.org/grpc"
+
+	pb "{{ .Module }}/proto"
+)
+
+// {{ .Name | pascal | ident }}Server implements {{ .Name | pascal | ident }} service.
+type {{ .Name | pascal | ident }}Server struct {
+	pb.Unimplemented{{ .Name | pascal | ident }}Server
+}
+
+func init() {
+	services = append(services, func(s *grpc.Server) {
+		pb.Register{{ .Name | pascal | ident }}Server(s, &{{ .Name | pascal | ident }}Server{})
+	})
+}
+`))
+
+var _ = template.Must(rootTemplate.New(GRPCRegistry).Parse(`package grpc
+
+import "google.golang.org/grpc"
+
+// services register gRPC services implementations in a server. Services add
+// themselves to the registry in their init functions.
+var services []func(*grpc.Server)
+
+// Register registers all services in the server.
+func Register(s *grpc.Server) {
+	for _, register := range services {
+		register(s)
+	}
+}
+`))
+
+var _ = template.Must(rootTemplate.New(GRPCServer).Parse(`package grpc
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	handler "{{ .Module }}/handler/grpc"
+)
+
+const defaultAddress = ":50051"
+
+func Start() {
+	lis, err := net.Listen("tcp", defaultAddress)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	handler.Register(s)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		log.Print("service stopping")
+		s.GracefulStop()
+	}()
+
+	log.Printf("service listening at %s", defaultAddress)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("service stopped: %v", err)
+	}
+}
+`))
+
+var _ = template.Must(rootTemplate.New(GRPCService).Parse(`package main
+
+import (
+	server "{{ .Module }}/server/grpc"
+)
+
+func main() {
+	server.Start()
+}
+`))
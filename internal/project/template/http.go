@@ -3,30 +3,61 @@ package template
 import "text/template"
 
 type HTTPEndpointData struct {
-	Name string
-	Path string
+	Name   string
+	Path   string
+	Params map[string]interface{} // method
 }
 
-var _ = template.Must(rootTemplate.New(HTTPEndpoint).Parse(`package http
+var _ = template.Must(rootTemplate.New(HTTPEndpoint).Parse(`
+{{- $route := print (camel .Name) "Route" | ident -}}
+{{- $handler := print (camel .Name) "Handler" | ident -}}
+package http
 
 import (
 	"fmt"
 	"net/http"
 )
 
-const {{ .Name }}Route = "{{ .Path }}"
+const {{ $route }} = {{ .Path | quote }}
 
 func init() {
-	router.Handle({{ .Name }}Route, {{ .Name }}Handler())
+	router.Handle({{ $route }}, {{ $handler }}())
 }
 
-func {{ .Name }}Handler() http.Handler {
+func {{ $handler }}() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "{{ .Params.method }}" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
 		fmt.Fprint(w, "OK")
 	})
 }
 `))
 
+var _ = template.Must(rootTemplate.New(HTTPEndpointTest).Parse(`
+{{- $route := print (camel .Name) "Route" | ident -}}
+{{- $handler := print (camel .Name) "Handler" | ident -}}
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_{{ $handler }}(t *testing.T) {
+	r := httptest.NewRequest("{{ .Params.method }}", {{ $route }}, nil)
+	w := httptest.NewRecorder()
+
+	{{ $handler }}().ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+`))
+
 var _ = template.Must(rootTemplate.New(HTTPRouter).Parse(`package http
 
 import "net/http"
@@ -41,9 +72,10 @@ func Mux() *http.ServeMux {
 var _ = template.Must(rootTemplate.New(HTTPServer).Parse(`package http
 
 import (
-	handler "{{ .Module }}/handler/http"
 	"log"
 	"net/http"
+
+	handler "{{ .Module }}/handler/http"
 )
 
 const defaultAddress = ":8080"
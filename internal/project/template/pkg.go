@@ -0,0 +1,33 @@
+package template
+
+import "text/template"
+
+var _ = template.Must(rootTemplate.New(PkgPackage).Parse(`package {{ .Package }}
+`))
+
+var _ = template.Must(rootTemplate.New(PkgDoc).Parse(`// Package {{ .Package }} TODO: describe the package.
+package {{ .Package }}
+`))
+
+var _ = template.Must(rootTemplate.New(PkgExample).Parse(`package {{ .Package }}
+
+import "fmt"
+
+func Example() {
+	fmt.Println("{{ .Package }}")
+	// Output: {{ .Package }}
+}
+`))
+
+var _ = template.Must(rootTemplate.New(PkgType).Parse(`package {{ .Package }}
+
+// {{ .Name | ident }} TODO: describe the type.
+type {{ .Name | ident }} struct{}
+`))
+
+var _ = template.Must(rootTemplate.New(PkgExampleFunc).Parse(`package {{ .Package }}
+
+func Example_{{ .Name | camel }}() {
+	// Output:
+}
+`))
@@ -1,21 +1,173 @@
 package template
 
-import "text/template"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
 
 const (
+	// GoMod a go.mod template name.
+	GoMod = "go_mod"
 	// HTTPEndpoint an http endpoint template name.
 	HTTPEndpoint = "http_endpoint"
+	// HTTPEndpointTest an http endpoint test template name.
+	HTTPEndpointTest = "http_endpoint_test"
 	// HTTPRouter an http router template name.
 	HTTPRouter = "http_router"
 	// HTTPServer an http server template name.
 	HTTPServer = "http_server"
 	// HTTPService an http service template name.
 	HTTPService = "http_service"
+	// CLIMain a command line application main file template name.
+	CLIMain = "cli_main"
+	// CLIRoot a command line application root command template name.
+	CLIRoot = "cli_root"
+	// CLIFlag a command line application flag helpers template name.
+	CLIFlag = "cli_flag"
+	// CLICommand a command line application subcommand template name.
+	CLICommand = "cli_command"
+	// GRPCHandler a gRPC service implementation template name.
+	GRPCHandler = "grpc_handler"
+	// GRPCProto a gRPC service protocol buffers definition template name.
+	GRPCProto = "grpc_proto"
+	// GRPCRegistry a gRPC services registry template name.
+	GRPCRegistry = "grpc_registry"
+	// GRPCServer a gRPC server template name.
+	GRPCServer = "grpc_server"
+	// GRPCService a gRPC service template name.
+	GRPCService = "grpc_service"
+	// PkgPackage a package root file template name.
+	PkgPackage = "pkg_package"
+	// PkgDoc a package documentation template name.
+	PkgDoc = "pkg_doc"
+	// PkgExample a package example test template name.
+	PkgExample = "pkg_example"
+	// PkgType a package type template name.
+	PkgType = "pkg_type"
+	// PkgExampleFunc a package example function template name.
+	PkgExampleFunc = "pkg_example_func"
 )
 
-var rootTemplate = template.New("__chef_root__")
+// ProjectData is the data project layout templates are executed with.
+type ProjectData struct {
+	Module    string       // Go module name
+	GoVersion string       // Go version of the module
+	Package   string       // Go package name derived from the project name
+	Require   []Dependency // modules required by the project
+}
+
+// ModulePath returns the Go module name.
+func (d ProjectData) ModulePath() string {
+	return d.Module
+}
+
+// Dependency is a module required by a project.
+type Dependency struct {
+	Path    string
+	Version string
+}
+
+// Ext is a file extension of user templates.
+const Ext = ".tmpl"
+
+// versionLen is the length of the template version.
+const versionLen = 12
+
+var rootTemplate = template.New("__chef_root__").Funcs(funcs)
 
 // Get returns the template registered with the given name.
 func Get(name string) *template.Template {
 	return rootTemplate.Lookup(name)
 }
+
+// Load parses all files with the template extension found in the directory
+// dir and registers them by the file name without extension. Loaded
+// templates override registered templates with the same name.
+//
+// When any of the files fails to parse no templates are registered.
+func Load(dir string) error {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%q is not a directory", dir)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return err
+	}
+
+	root, err := rootTemplate.Clone()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), Ext)
+		if _, err := root.New(name).Parse(string(b)); err != nil {
+			return errors.Wrapf(err, "failed to parse template %s", file)
+		}
+	}
+
+	rootTemplate = root
+	return nil
+}
+
+// Cleaner is implemented by testing.T and testing.B.
+type Cleaner interface {
+	Cleanup(func())
+	Helper()
+}
+
+// KeepRegistered restores templates registered before the test when the
+// test completes. It is intended to be used by tests that load templates.
+func KeepRegistered(c Cleaner) {
+	c.Helper()
+	root := rootTemplate
+	c.Cleanup(func() { rootTemplate = root })
+}
+
+// ParseFile parses template from the file. The template is named after the
+// file base name without extension. It can use the registered templates.
+func ParseFile(file string) (*template.Template, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := rootTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	tmpl, err := root.New(name).Parse(string(b))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template %s", file)
+	}
+	return tmpl, nil
+}
+
+// Version returns version of the template. The version changes when template
+// source changes.
+func Version(t *template.Template) string {
+	if t == nil || t.Tree == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(t.Tree.Root.String()))
+	return hex.EncodeToString(sum[:])[:versionLen]
+}
@@ -2,6 +2,8 @@ package template_test
 
 import (
 	"bytes"
+	"os"
+	"path"
 	"strings"
 	"testing"
 
@@ -15,6 +17,10 @@ func TestTemplateRegistry(t *testing.T) {
 		desc string
 		name string
 	}{
+		{
+			desc: "has a go.mod template",
+			name: template.GoMod,
+		},
 		{
 			desc: "has an http endpoint template",
 			name: template.HTTPEndpoint,
@@ -31,6 +37,66 @@ func TestTemplateRegistry(t *testing.T) {
 			desc: "has an http service template",
 			name: template.HTTPService,
 		},
+		{
+			desc: "has a grpc handler template",
+			name: template.GRPCHandler,
+		},
+		{
+			desc: "has a grpc proto template",
+			name: template.GRPCProto,
+		},
+		{
+			desc: "has a grpc registry template",
+			name: template.GRPCRegistry,
+		},
+		{
+			desc: "has a grpc server template",
+			name: template.GRPCServer,
+		},
+		{
+			desc: "has a grpc service template",
+			name: template.GRPCService,
+		},
+		{
+			desc: "has a package root file template",
+			name: template.PkgPackage,
+		},
+		{
+			desc: "has a package documentation template",
+			name: template.PkgDoc,
+		},
+		{
+			desc: "has a package example test template",
+			name: template.PkgExample,
+		},
+		{
+			desc: "has a package type template",
+			name: template.PkgType,
+		},
+		{
+			desc: "has a package example function template",
+			name: template.PkgExampleFunc,
+		},
+		{
+			desc: "has an http endpoint test template",
+			name: template.HTTPEndpointTest,
+		},
+		{
+			desc: "has a cli main template",
+			name: template.CLIMain,
+		},
+		{
+			desc: "has a cli root command template",
+			name: template.CLIRoot,
+		},
+		{
+			desc: "has a cli flag helpers template",
+			name: template.CLIFlag,
+		},
+		{
+			desc: "has a cli command template",
+			name: template.CLICommand,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -42,8 +108,9 @@ func TestTemplateRegistry(t *testing.T) {
 
 func TestHttpEndpointTemplate(t *testing.T) {
 	data := template.HTTPEndpointData{
-		Name: "health",
-		Path: "/health_path",
+		Name:   "health",
+		Path:   "/health_path",
+		Params: map[string]interface{}{"method": "POST"},
 	}
 	tmpl := template.Get(template.HTTPEndpoint)
 	var out bytes.Buffer
@@ -66,4 +133,173 @@ func TestHttpEndpointTemplate(t *testing.T) {
 	t.Run("defines route handler", func(t *testing.T) {
 		assert.True(t, strings.Contains(outs, "func healthHandler() http.Handler"))
 	})
+
+	t.Run("checks request method", func(t *testing.T) {
+		assert.True(t, strings.Contains(outs, `if r.Method != "POST" {`))
+	})
+}
+
+func TestHttpEndpointTemplateIdentifiers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		route   string
+		handler string
+	}{
+		{name: "user-profile", route: "userProfileRoute", handler: "userProfileHandler"},
+		{name: "order_items", route: "orderItemsRoute", handler: "orderItemsHandler"},
+		{name: "2fa", route: "_2faRoute", handler: "_2faHandler"},
+		{name: "type", route: "typeRoute", handler: "typeHandler"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			data := template.HTTPEndpointData{
+				Name:   tC.name,
+				Path:   "/" + tC.name,
+				Params: map[string]interface{}{"method": "GET"},
+			}
+			var out bytes.Buffer
+			err := template.Get(template.HTTPEndpoint).Execute(&out, data)
+			require.NoError(t, err)
+
+			assert.Contains(t, out.String(), "const "+tC.route+` = "/`+tC.name+`"`)
+			assert.Contains(t, out.String(), "func "+tC.handler+"() http.Handler")
+		})
+	}
+}
+
+func TestHttpEndpointTestTemplate(t *testing.T) {
+	data := template.HTTPEndpointData{Name: "health", Params: map[string]interface{}{"method": "GET"}}
+	tmpl := template.Get(template.HTTPEndpointTest)
+	var out bytes.Buffer
+	err := tmpl.Execute(&out, data)
+	require.NoError(t, err)
+	outs := out.String()
+
+	assert.NotContains(t, outs, "<no value>")
+	assert.Contains(t, outs, "func Test_healthHandler(t *testing.T)")
+	assert.Contains(t, outs, "healthHandler().ServeHTTP(w, r)")
+}
+
+func TestGoModTemplate(t *testing.T) {
+	data := template.ProjectData{
+		Module:    "github.com/antklim/cheftest",
+		GoVersion: "1.23",
+	}
+	tmpl := template.Get(template.GoMod)
+	var out bytes.Buffer
+	err := tmpl.Execute(&out, data)
+	require.NoError(t, err)
+	assert.Equal(t, "module github.com/antklim/cheftest\n\ngo 1.23\n", out.String())
+
+	t.Run("renders required modules", func(t *testing.T) {
+		data.Require = []template.Dependency{
+			{Path: "github.com/spf13/cobra", Version: "v1.8.1"},
+			{Path: "github.com/pkg/errors", Version: "v0.9.1"},
+		}
+		var out bytes.Buffer
+		err := tmpl.Execute(&out, data)
+		require.NoError(t, err)
+		expected := "module github.com/antklim/cheftest\n\ngo 1.23\n\n" +
+			"require (\n\tgithub.com/spf13/cobra v1.8.1\n\tgithub.com/pkg/errors v0.9.1\n)\n"
+		assert.Equal(t, expected, out.String())
+	})
+}
+
+func TestLoad(t *testing.T) {
+	template.KeepRegistered(t)
+
+	t.Run("registers templates from directory", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(path.Join(dir, "chef_repo.tmpl"), []byte("package {{ .Name }}"), 0600)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(dir, "chef_readme.md"), []byte("# readme"), 0600)
+		require.NoError(t, err)
+
+		err = template.Load(dir)
+		require.NoError(t, err)
+
+		tmpl := template.Get("chef_repo")
+		require.NotNil(t, tmpl)
+		var out bytes.Buffer
+		err = tmpl.Execute(&out, struct{ Name string }{Name: "repo"})
+		require.NoError(t, err)
+		assert.Equal(t, "package repo", out.String())
+
+		assert.Nil(t, template.Get("chef_readme"))
+	})
+
+	t.Run("overrides registered templates", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(path.Join(dir, template.HTTPServer+".tmpl"), []byte("package server"), 0600)
+		require.NoError(t, err)
+
+		err = template.Load(dir)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		err = template.Get(template.HTTPServer).Execute(&out, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "package server", out.String())
+	})
+
+	t.Run("fails when directory does not exist", func(t *testing.T) {
+		err := template.Load("foo")
+		assert.EqualError(t, err, "stat foo: no such file or directory")
+	})
+
+	t.Run("does not register templates when any template is invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(path.Join(dir, "chef_a.tmpl"), []byte("package a"), 0600)
+		require.NoError(t, err)
+		err = os.WriteFile(path.Join(dir, "chef_b.tmpl"), []byte("package {{ .Name"), 0600)
+		require.NoError(t, err)
+
+		err = template.Load(dir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse template "+path.Join(dir, "chef_b.tmpl"))
+		assert.Nil(t, template.Get("chef_a"))
+	})
+}
+
+func TestParseFile(t *testing.T) {
+	t.Run("parses template named after file", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "repo.tmpl")
+		err := os.WriteFile(file, []byte("package {{ .Name }}"), 0600)
+		require.NoError(t, err)
+
+		tmpl, err := template.ParseFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "repo", tmpl.Name())
+
+		var out bytes.Buffer
+		err = tmpl.Execute(&out, struct{ Name string }{Name: "repo"})
+		require.NoError(t, err)
+		assert.Equal(t, "package repo", out.String())
+		assert.Nil(t, template.Get("repo"))
+	})
+
+	t.Run("parses template using functions library", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "repo.tmpl")
+		err := os.WriteFile(file, []byte("package {{ .Name | pkg }}\n\ntype {{ .Name | pascal }}Repo struct{}"), 0600)
+		require.NoError(t, err)
+
+		tmpl, err := template.ParseFile(file)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		err = tmpl.Execute(&out, struct{ Name string }{Name: "user-profile"})
+		require.NoError(t, err)
+		assert.Equal(t, "package userprofile\n\ntype UserProfileRepo struct{}", out.String())
+	})
+
+	t.Run("fails when template is invalid", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "repo.tmpl")
+		err := os.WriteFile(file, []byte("package {{ .Name"), 0600)
+		require.NoError(t, err)
+
+		tmpl, err := template.ParseFile(file)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse template "+file)
+		assert.Nil(t, tmpl)
+	})
 }
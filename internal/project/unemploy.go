@@ -0,0 +1,214 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+)
+
+// UnemployComponent removes the nodes created by employing the component from
+// the project layout and the file system. It fails when any of the files was
+// modified since it was generated, unless force is set. It returns locations of
+// the removed files relative to the project.
+func (p *Project) UnemployComponent(component, name string, force bool) ([]string, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	nodes, idx, err := p.employedInstance(component, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.checkModified(nodes, force); err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, 0, len(nodes))
+	for _, cn := range nodes {
+		loc := path.Join(cn.loc, cn.n.Name())
+		if err := p.opts.out.RemoveAll(path.Join(p.loc, loc)); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove %q", loc)
+		}
+		if err := p.lout.RemoveNode(loc); err != nil {
+			return nil, errors.Wrap(err, "failed to remove node from layout")
+		}
+		delete(p.enodes, loc)
+		removed = append(removed, loc)
+	}
+
+	p.employed = slices.Delete(p.employed, idx, idx+1)
+	if err := p.writeNotation(p.opts.out, p.loc); err != nil {
+		return nil, errors.Wrap(err, "project notation write failed")
+	}
+	if err := p.updateBaseline(nil, removed); err != nil {
+		return nil, errors.Wrap(err, "project baseline write failed")
+	}
+	return removed, nil
+}
+
+// RenameComponent renames the nodes created by employing the component. Not
+// modified files are generated again with the new name, modified files are
+// moved as is when force is set. It returns locations of the renamed files
+// relative to the project.
+func (p *Project) RenameComponent(component, name, newName string, force bool) ([]string, error) {
+	if !p.inited {
+		return nil, errNotInited
+	}
+
+	nodes, idx, err := p.employedInstance(component, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.validateName(component, newName); err != nil {
+		return nil, err
+	}
+
+	newNodes, data, err := p.componentNodes(component, newName, p.employed[idx].Params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cn := range newNodes {
+		loc := path.Join(cn.loc, cn.n.Name())
+		if p.lout.FindNode(loc) != nil {
+			return nil, fmt.Errorf("node %q already exists in %q", cn.n.Name(), cn.loc)
+		}
+		if _, err := p.opts.out.Stat(path.Join(p.loc, loc)); err == nil {
+			return nil, fmt.Errorf("file %q already exists", loc)
+		}
+	}
+
+	modified, err := p.checkModified(nodes, force)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := p.baseline()
+	if err != nil {
+		return nil, errors.Wrap(err, "project baseline read failed")
+	}
+
+	renamed := make([]string, 0, len(nodes))
+	for i, cn := range nodes {
+		loc := path.Join(cn.loc, cn.n.Name())
+		nn := newNodes[i]
+		nloc := path.Join(nn.loc, nn.n.Name())
+
+		if modified[loc] {
+			err = p.opts.out.Rename(path.Join(p.loc, loc), path.Join(p.loc, nloc))
+		} else {
+			err = p.rebuild(nn, data, loc)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to rename %q", loc)
+		}
+
+		// The moved file keeps the baseline it was generated with.
+		if v, ok := b[loc]; ok && modified[loc] {
+			b[nloc] = v
+		} else if !modified[loc] {
+			if err := recordBaseline(b, p.opts.out, p.loc, []string{nloc}); err != nil {
+				return nil, errors.Wrapf(err, "failed to rename %q", loc)
+			}
+		}
+		delete(b, loc)
+
+		if err := p.lout.RemoveNode(loc); err != nil {
+			return nil, errors.Wrap(err, "failed to remove node from layout")
+		}
+		if err := p.lout.AddNode(nn.n, nn.loc); err != nil {
+			return nil, errors.Wrap(err, "failed to add node to layout")
+		}
+		delete(p.enodes, loc)
+		renamed = append(renamed, nloc)
+	}
+
+	p.own(component, newNodes, data)
+	p.employed[idx].Name = data.Name
+	p.employed[idx].Path = renamed[0]
+	if err := p.writeNotation(p.opts.out, p.loc); err != nil {
+		return nil, errors.Wrap(err, "project notation write failed")
+	}
+	if err := writeBaseline(p.opts.out, p.loc, b); err != nil {
+		return nil, errors.Wrap(err, "project baseline write failed")
+	}
+	return renamed, nil
+}
+
+// rebuild builds the component node with the data and removes the file at the
+// old location.
+func (p *Project) rebuild(cn componentNode, data componentData, loc string) error {
+	if err := cn.n.Build(p.opts.out, path.Join(p.loc, cn.loc), data); err != nil {
+		return err
+	}
+	return p.opts.out.RemoveAll(path.Join(p.loc, loc))
+}
+
+// employedInstance returns the nodes of the employed component instance and
+// the index of the instance in the employed log.
+func (p *Project) employedInstance(component, name string) ([]componentNode, int, error) {
+	c, ok := p.components[component]
+	if !ok {
+		return nil, 0, fmt.Errorf("unregistered component %q", component)
+	}
+
+	_, tname, err := c.elementName(name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	idx := slices.IndexFunc(p.employed, func(e chef.Employed) bool {
+		return e.Component == component && e.Name == tname
+	})
+	if idx == -1 {
+		return nil, 0, fmt.Errorf("%q is not employed as %q component", tname, component)
+	}
+
+	nodes, _, err := p.componentNodes(component, name, p.employed[idx].Params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return nodes, idx, nil
+}
+
+// checkModified returns the locations of the component nodes files which
+// content differs from a fresh render. It fails when files were modified
+// unless force is set. Missing files are not reported.
+func (p *Project) checkModified(nodes []componentNode, force bool) (map[string]bool, error) {
+	modified := make(map[string]bool)
+	for _, cn := range nodes {
+		loc := path.Join(cn.loc, cn.n.Name())
+		n, ok := p.lout.FindNode(loc).(*node.Fnode)
+		if !ok {
+			return nil, fmt.Errorf("node %q not found in layout", loc)
+		}
+
+		expected, err := n.Render(p.nodeData(loc))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render node %q", loc)
+		}
+		actual, err := p.opts.out.FileContent(path.Join(p.loc, loc))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(expected, actual) {
+			if !force {
+				return nil, fmt.Errorf("file %q was modified since it was generated", loc)
+			}
+			modified[loc] = true
+		}
+	}
+	return modified, nil
+}
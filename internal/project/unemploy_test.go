@@ -0,0 +1,161 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func employedProject(t *testing.T) (*project.Project, string) {
+	t.Helper()
+
+	p := project.New("project",
+		project.WithRoot(t.TempDir()),
+		project.WithServer("http"),
+		project.WithModule("project.git"))
+	err := p.Init()
+	require.NoError(t, err)
+	loc, err := p.Build()
+	require.NoError(t, err)
+	_, err = p.EmployComponent("http_handler", "echo", nil)
+	require.NoError(t, err)
+	return p, loc
+}
+
+func readEmployed(t *testing.T, loc string) []chef.Employed {
+	t.Helper()
+
+	f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+	require.NoError(t, err)
+	defer f.Close()
+	n, err := chef.ReadNotation(f)
+	require.NoError(t, err)
+	return n.Employed
+}
+
+func TestProjectUnemployComponent(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.UnemployComponent("http_handler", "echo", false)
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("fails when component instance not employed", func(t *testing.T) {
+		p, _ := employedProject(t)
+		_, err := p.UnemployComponent("http_handler", "ping", false)
+		assert.EqualError(t, err, `"ping" is not employed as "http_handler" component`)
+	})
+
+	t.Run("fails when file modified", func(t *testing.T) {
+		p, loc := employedProject(t)
+		err := os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = p.UnemployComponent("http_handler", "echo", false)
+		assert.EqualError(t, err, `file "handler/http/echo.go" was modified since it was generated`)
+		assert.NotNil(t, p.Layout().FindNode("handler/http/echo.go"))
+	})
+
+	t.Run("removes component instance", func(t *testing.T) {
+		p, loc := employedProject(t)
+
+		removed, err := p.UnemployComponent("http_handler", "echo.go", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"handler/http/echo.go", "handler/http/echo_test.go"}, removed)
+
+		_, err = os.Stat(path.Join(loc, "handler/http/echo.go"))
+		assert.True(t, os.IsNotExist(err))
+		assert.Nil(t, p.Layout().FindNode("handler/http/echo.go"))
+		assert.Empty(t, readEmployed(t, loc))
+	})
+
+	t.Run("removes modified component instance when forced", func(t *testing.T) {
+		p, loc := employedProject(t)
+		err := os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = p.UnemployComponent("http_handler", "echo", true)
+		require.NoError(t, err)
+
+		_, err = os.Stat(path.Join(loc, "handler/http/echo.go"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestProjectRenameComponent(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.RenameComponent("http_handler", "echo", "ping", false)
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("fails when new name is taken", func(t *testing.T) {
+		p, _ := employedProject(t)
+		_, err := p.EmployComponent("http_handler", "ping", nil)
+		require.NoError(t, err)
+
+		_, err = p.RenameComponent("http_handler", "echo", "ping", false)
+		assert.EqualError(t, err, `node "ping.go" already exists in "handler/http"`)
+	})
+
+	t.Run("fails when new name is invalid", func(t *testing.T) {
+		p, _ := employedProject(t)
+		_, err := p.RenameComponent("http_handler", "echo", "../ping", false)
+		assert.EqualError(t, err, `invalid name "../ping": must not contain path separators, try "ping"`)
+		assert.NotNil(t, p.Layout().FindNode("handler/http/echo.go"))
+	})
+
+	t.Run("fails when file modified", func(t *testing.T) {
+		p, loc := employedProject(t)
+		err := os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = p.RenameComponent("http_handler", "echo", "ping", false)
+		assert.EqualError(t, err, `file "handler/http/echo.go" was modified since it was generated`)
+	})
+
+	t.Run("generates component instance with new name", func(t *testing.T) {
+		p, loc := employedProject(t)
+
+		renamed, err := p.RenameComponent("http_handler", "echo", "ping", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"handler/http/ping.go", "handler/http/ping_test.go"}, renamed)
+
+		_, err = os.Stat(path.Join(loc, "handler/http/echo.go"))
+		assert.True(t, os.IsNotExist(err))
+		data, err := os.ReadFile(path.Join(loc, "handler/http/ping.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "ping")
+		assert.NotContains(t, string(data), "echo")
+
+		assert.Nil(t, p.Layout().FindNode("handler/http/echo.go"))
+		assert.NotNil(t, p.Layout().FindNode("handler/http/ping.go"))
+
+		employed := readEmployed(t, loc)
+		require.Len(t, employed, 1)
+		assert.Equal(t, "ping", employed[0].Name)
+		assert.Equal(t, "handler/http/ping.go", employed[0].Path)
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("moves modified component instance when forced", func(t *testing.T) {
+		p, loc := employedProject(t)
+		err := os.WriteFile(path.Join(loc, "handler/http/echo.go"), []byte("package http\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = p.RenameComponent("http_handler", "echo", "ping", true)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(loc, "handler/http/ping.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package http\n", string(data))
+	})
+}
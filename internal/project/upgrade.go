@@ -0,0 +1,123 @@
+package project
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/antklim/chef/internal/merge"
+	"github.com/antklim/chef/internal/project/template"
+	"github.com/pkg/errors"
+)
+
+// upgradeLabels name the sides of the upgrade conflicts: the file in the
+// project and the file rendered with the current templates.
+var upgradeLabels = merge.Labels{Ours: "current", Theirs: "upgraded"}
+
+// UpgradeReport describes the files changed by the project upgrade.
+type UpgradeReport struct {
+	Merged     []string // locations of cleanly merged files relative to the project
+	Conflicted []string // locations of files with conflict markers relative to the project
+}
+
+// upgradeEntry is a project file the upgrade writes.
+type upgradeEntry struct {
+	loc      string // location relative to the project
+	perm     fs.FileMode
+	data     []byte
+	conflict bool
+}
+
+// Upgrade renders the project files with the current templates and merges the
+// template changes into the files in the project. The changes are found
+// against the baseline the files were generated with, thus the project changes
+// are kept. Lines changed both in the project and by the templates are written
+// between conflict markers. Every difference of a file without baseline is a
+// conflict. Missing files are not created.
+//
+// The baseline and the employed components versions are updated to the current
+// templates. All files are merged before any change is made.
+func (p *Project) Upgrade() (UpgradeReport, error) {
+	if !p.inited {
+		return UpgradeReport{}, errNotInited
+	}
+
+	base, err := p.baseline()
+	if err != nil {
+		return UpgradeReport{}, errors.Wrap(err, "project baseline read failed")
+	}
+
+	entries, upgraded, err := p.upgradeEntries(base)
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+
+	var report UpgradeReport
+	for _, e := range entries {
+		if err := p.opts.out.WriteFile(path.Join(p.loc, e.loc), e.data, e.perm); err != nil {
+			return report, errors.Wrapf(err, "failed to write file %q", e.loc)
+		}
+		if e.conflict {
+			report.Conflicted = append(report.Conflicted, e.loc)
+		} else {
+			report.Merged = append(report.Merged, e.loc)
+		}
+	}
+
+	if err := writeBaseline(p.opts.out, p.loc, upgraded); err != nil {
+		return report, errors.Wrap(err, "project baseline write failed")
+	}
+
+	for i, e := range p.employed {
+		if n, ok := p.lout.FindNode(e.Path).(*node.Fnode); ok {
+			p.employed[i].Version = template.Version(n.Template())
+		}
+	}
+	if err := p.writeNotation(p.opts.out, p.loc); err != nil {
+		return report, errors.Wrap(err, "project notation write failed")
+	}
+	return report, nil
+}
+
+// upgradeEntries returns the files the project upgrade writes and the upgraded
+// baseline. The baseline of missing files is kept.
+func (p *Project) upgradeEntries(base chef.Baseline) ([]upgradeEntry, chef.Baseline, error) {
+	var entries []upgradeEntry
+	upgraded := make(chef.Baseline)
+	for _, loc := range p.filePaths() {
+		file := path.Join(p.loc, loc)
+		fi, err := p.opts.out.Stat(file)
+		if err != nil || fi.IsDir() {
+			if b, ok := base[loc]; ok {
+				upgraded[loc] = b
+			}
+			continue
+		}
+
+		n := p.lout.FindNode(loc).(*node.Fnode)
+		theirs, err := n.Render(p.nodeData(loc))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to render node %q", loc)
+		}
+		ours, err := p.opts.out.FileContent(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		upgraded[loc] = string(theirs)
+
+		var merged []byte
+		var conflicts int
+		if b, ok := base[loc]; ok {
+			merged, conflicts = merge.Merge([]byte(b), ours, theirs, upgradeLabels)
+		} else {
+			merged, conflicts = merge.MergeWithoutBase(ours, theirs, upgradeLabels)
+		}
+		if bytes.Equal(merged, ours) {
+			continue
+		}
+		entries = append(entries, upgradeEntry{loc: loc, perm: fi.Mode().Perm(), data: merged, conflict: conflicts > 0})
+	}
+	return entries, upgraded, nil
+}
@@ -0,0 +1,161 @@
+package project_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/antklim/chef/internal/chef"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	repoTmplV1 = `package provider
+
+// {{ .Name | pascal }} is a repository. v1
+type {{ .Name | pascal }} struct{}
+`
+
+	repoTmplV2 = `package provider
+
+// {{ .Name | pascal }} is a repository. v2
+type {{ .Name | pascal }} struct{}
+
+// New{{ .Name | pascal }} creates a repository.
+func New{{ .Name | pascal }}() *{{ .Name | pascal }} {
+	return &{{ .Name | pascal }}{}
+}
+`
+)
+
+// upgradedProject returns a project with the repo component employed as user
+// and order. The component template is upgraded and the project is restored
+// from the notation.
+func upgradedProject(t *testing.T) (*project.Project, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	tmplFile := path.Join(root, "repo.tmpl")
+	require.NoError(t, os.WriteFile(tmplFile, []byte(repoTmplV1), 0600))
+
+	p := project.New("project", project.WithRoot(root), project.WithServer("http"), project.WithModule("project.git"))
+	require.NoError(t, p.Init())
+	loc, err := p.Build()
+	require.NoError(t, err)
+	require.NoError(t, p.AddComponent(chef.Component{Name: "repo", Loc: "provider", File: tmplFile}, false))
+	_, err = p.EmployComponent("repo", "user", nil)
+	require.NoError(t, err)
+	_, err = p.EmployComponent("repo", "order", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(tmplFile, []byte(repoTmplV2), 0600))
+
+	f, err := os.Open(path.Join(loc, chef.DefaultNotationFileName))
+	require.NoError(t, err)
+	defer f.Close()
+	n, err := chef.ReadNotation(f)
+	require.NoError(t, err)
+
+	p = project.New("project", project.WithRoot(root), project.WithNotation(n))
+	require.NoError(t, p.Init())
+	return p, loc
+}
+
+// editFile replaces old with new in the project file.
+func editFile(t *testing.T, file, old, new string) {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Contains(t, string(data), old)
+	err = os.WriteFile(file, []byte(strings.Replace(string(data), old, new, 1)), 0644)
+	require.NoError(t, err)
+}
+
+func TestProjectUpgrade(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("project")
+		_, err := p.Upgrade()
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("applies template changes to not modified files", func(t *testing.T) {
+		p, loc := upgradedProject(t)
+
+		report, err := p.Upgrade()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"provider/user.go", "provider/order.go"}, report.Merged)
+		assert.Empty(t, report.Conflicted)
+
+		findings, err := p.Doctor()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+
+		report, err = p.Upgrade()
+		require.NoError(t, err)
+		assert.Empty(t, report.Merged)
+		assert.Empty(t, report.Conflicted)
+
+		data, err := os.ReadFile(path.Join(loc, "provider/user.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "func NewUser() *User {")
+	})
+
+	t.Run("merges template changes with project changes", func(t *testing.T) {
+		p, loc := upgradedProject(t)
+		editFile(t, path.Join(loc, "provider/user.go"), "package provider\n", "package provider // users\n")
+		editFile(t, path.Join(loc, "provider/order.go"), "Order is a repository. v1", "Order is an orders repository.")
+
+		report, err := p.Upgrade()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"provider/user.go"}, report.Merged)
+		assert.Equal(t, []string{"provider/order.go"}, report.Conflicted)
+
+		data, err := os.ReadFile(path.Join(loc, "provider/user.go"))
+		require.NoError(t, err)
+		expected := "package provider // users\n\n// User is a repository. v2\ntype User struct{}\n\n" +
+			"// NewUser creates a repository.\nfunc NewUser() *User {\n\treturn &User{}\n}\n"
+		assert.Equal(t, expected, string(data))
+
+		data, err = os.ReadFile(path.Join(loc, "provider/order.go"))
+		require.NoError(t, err)
+		expected = "package provider\n\n<<<<<<< current\n// Order is an orders repository.\n=======\n" +
+			"// Order is a repository. v2\n>>>>>>> upgraded\ntype Order struct{}\n"
+		assert.True(t, strings.HasPrefix(string(data), expected), string(data))
+
+		// conflicts are resolved in the project, the next upgrade keeps them
+		report, err = p.Upgrade()
+		require.NoError(t, err)
+		assert.Empty(t, report.Merged)
+		assert.Empty(t, report.Conflicted)
+	})
+
+	t.Run("marks every difference of files without baseline", func(t *testing.T) {
+		p, loc := upgradedProject(t)
+		require.NoError(t, os.Remove(path.Join(loc, chef.DefaultBaselineFileName)))
+
+		report, err := p.Upgrade()
+		require.NoError(t, err)
+		assert.Empty(t, report.Merged)
+		assert.ElementsMatch(t, []string{"provider/user.go", "provider/order.go"}, report.Conflicted)
+
+		_, err = os.Stat(path.Join(loc, chef.DefaultBaselineFileName))
+		assert.NoError(t, err)
+	})
+
+	t.Run("updates employed components versions", func(t *testing.T) {
+		p, loc := upgradedProject(t)
+		before := readEmployed(t, loc)
+
+		_, err := p.Upgrade()
+		require.NoError(t, err)
+
+		after := readEmployed(t, loc)
+		require.Len(t, after, 2)
+		assert.NotEqual(t, before[0].Version, after[0].Version)
+		assert.NotEqual(t, before[1].Version, after[1].Version)
+	})
+}
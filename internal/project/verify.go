@@ -0,0 +1,126 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/pkg/errors"
+)
+
+// verifyCommands are the commands run in the project location to verify
+// the generated project.
+var verifyCommands = [][]string{
+	{"go", "build", "./..."},
+	{"go", "vet", "./..."},
+}
+
+// tidyCommand resolves dependencies of the projects requiring other modules.
+var tidyCommand = []string{"go", "mod", "tidy"}
+
+// issueRe matches compiler and vet diagnostics, for example:
+//
+//	handler/http/router.go:5:2: undefined: foo
+//	vet: ./main.go:8:2: unreachable code
+var issueRe = regexp.MustCompile(`^(?:vet: )?(?:\./)?([^\s:]+\.go):(\d+(?::\d+)?): (.*)$`)
+
+// Issue describes a problem found in a generated file.
+type Issue struct {
+	Path     string // file location relative to the project location
+	Pos      string // line and column of the problem in the file
+	Msg      string
+	Node     bool   // true when the file was produced by a layout node
+	Template string // name of the template produced the file
+}
+
+func (i Issue) String() string {
+	s := fmt.Sprintf("%s:%s: %s", i.Path, i.Pos, i.Msg)
+	if i.Node {
+		s += fmt.Sprintf(" (template %q)", i.Template)
+	}
+	return s
+}
+
+// VerifyError is returned when a generated project fails verification.
+type VerifyError struct {
+	Cmd    string  // the failed command
+	Issues []Issue // problems found in generated files
+	Output string  // output of the failed command
+}
+
+func (e *VerifyError) Error() string {
+	if len(e.Issues) == 0 {
+		return fmt.Sprintf("%s failed:\n%s", e.Cmd, strings.TrimSpace(e.Output))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s failed:", e.Cmd)
+	for _, i := range e.Issues {
+		fmt.Fprintf(&b, "\n\t%s", i)
+	}
+	return b.String()
+}
+
+// Verify runs go build and go vet in the project location. Dependencies of
+// the project are resolved with go mod tidy beforehand. It returns
+// VerifyError pointing at layout nodes of broken files when any of the
+// commands fails.
+func (p *Project) Verify() error {
+	if !p.inited {
+		return errNotInited
+	}
+	if _, ok := p.opts.out.(fsys.OSFS); !ok {
+		return errors.New("project should be built to the OS file system to be verified")
+	}
+
+	commands := verifyCommands
+	if len(p.Dependencies()) > 0 {
+		commands = append([][]string{tidyCommand}, verifyCommands...)
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command(args[0], args[1:]...) //nolint:gosec
+		cmd.Dir = p.loc
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(*exec.ExitError); !ok {
+			return errors.Wrapf(err, "failed to run %s", strings.Join(args, " "))
+		}
+		return &VerifyError{
+			Cmd:    strings.Join(args, " "),
+			Issues: p.issues(out),
+			Output: string(out),
+		}
+	}
+	return nil
+}
+
+// issues parses command output and attributes found issues to layout nodes.
+func (p *Project) issues(out []byte) []Issue {
+	var issues []Issue
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		m := issueRe.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+
+		i := Issue{Path: path.Clean(m[1]), Pos: m[2], Msg: m[3]}
+		if n, ok := p.lout.FindNode(i.Path).(*node.Fnode); ok {
+			i.Node = true
+			if t := n.Template(); t != nil {
+				i.Template = t.Name()
+			}
+		}
+		issues = append(issues, i)
+	}
+	return issues
+}
@@ -0,0 +1,89 @@
+package project_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/antklim/chef/internal/fsys"
+	"github.com/antklim/chef/internal/layout"
+	"github.com/antklim/chef/internal/layout/node"
+	"github.com/antklim/chef/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectVerify(t *testing.T) {
+	t.Run("fails when project not inited", func(t *testing.T) {
+		p := project.New("cheftest")
+		err := p.Verify()
+		assert.EqualError(t, err, "project not inited")
+	})
+
+	t.Run("fails when project is not built to the OS file system", func(t *testing.T) {
+		root := t.TempDir()
+		mem := fsys.NewMemFS()
+		err := mem.Mkdir(root, 0755)
+		require.NoError(t, err)
+		p := project.New("cheftest", project.WithRoot(root), project.WithFS(mem))
+		err = p.Init()
+		require.NoError(t, err)
+
+		err = p.Verify()
+		assert.EqualError(t, err, "project should be built to the OS file system to be verified")
+	})
+
+	t.Run("verifies generated http service", func(t *testing.T) {
+		p := project.New("cheftest",
+			project.WithRoot(t.TempDir()),
+			project.WithServer("http"),
+			project.WithModule("github.com/antklim/cheftest"))
+		err := p.Init()
+		require.NoError(t, err)
+		loc, err := p.Build()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path.Join(loc, "go.mod"))
+		require.NoError(t, err)
+		assert.Equal(t, "module github.com/antklim/cheftest\n\ngo 1.23\n", string(data))
+
+		err = p.Verify()
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports issues against layout nodes", func(t *testing.T) {
+		broken := node.NewFnode("main.go", node.WithNewTemplate("broken_main", `package main
+
+func main() {
+	foo()
+}
+`))
+		l := layout.New(broken)
+		p := project.New("cheftest",
+			project.WithRoot(t.TempDir()),
+			project.WithModule("cheftest"),
+			project.WithLayout(l))
+		err := p.Init()
+		require.NoError(t, err)
+		_, err = p.Build()
+		require.NoError(t, err)
+
+		err = p.Verify()
+		var verr *project.VerifyError
+		require.True(t, errors.As(err, &verr))
+		assert.Equal(t, "go build ./...", verr.Cmd)
+		require.Len(t, verr.Issues, 1)
+
+		expected := project.Issue{
+			Path:     "main.go",
+			Pos:      "4:2",
+			Msg:      "undefined: foo",
+			Node:     true,
+			Template: "broken_main",
+		}
+		assert.Equal(t, expected, verr.Issues[0])
+		assert.EqualError(t, err, "go build ./... failed:\n\t"+
+			`main.go:4:2: undefined: foo (template "broken_main")`)
+	})
+}